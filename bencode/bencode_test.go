@@ -0,0 +1,78 @@
+package bencode
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	cases := []struct {
+		in       string
+		expected interface{}
+	}{
+		{"i42e", int64(42)},
+		{"i-7e", int64(-7)},
+		{"i0e", int64(0)},
+		{"4:spam", "spam"},
+		{"0:", ""},
+		{"le", []interface{}{}},
+		{"l4:spami3ee", []interface{}{"spam", int64(3)}},
+		{"d3:cow3:moo4:spaml1:a1:bee", map[string]interface{}{"cow": "moo", "spam": []interface{}{"a", "b"}}},
+	}
+	for _, c := range cases {
+		v, err := Decode([]byte(c.in))
+		if err != nil {
+			t.Errorf("Decode(%q) failed; %v", c.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(v, c.expected) {
+			t.Errorf("Decode(%q) = %#v; expected %#v", c.in, v, c.expected)
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	for _, in := range []string{"", "i42", "ie", "i-0e", "i03e", "5:spam", "l4:spam", "d3:cowe", "di1e3:mooe", "x", "i1ei2e", "04:spam"} {
+		if _, err := Decode([]byte(in)); err == nil {
+			t.Errorf("Decode(%q) should have errored", in)
+		}
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	v := map[string]interface{}{
+		"spam": []interface{}{"a", int64(-1), map[string]interface{}{}},
+		"cow":  "moo",
+	}
+	data, err := Encode(v)
+	if err != nil {
+		t.Fatalf("Encode failed; %v", err)
+	}
+	if expected := "d3:cow3:moo4:spaml1:ai-1edeee"; string(data) != expected {
+		t.Errorf("Encode = %q; expected %q", data, expected)
+	}
+	decoded, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode failed; %v", err)
+	}
+	if !reflect.DeepEqual(decoded, v) {
+		t.Errorf("Round trip produced %#v; expected %#v", decoded, v)
+	}
+	if _, err = Encode(3.14); err == nil {
+		t.Errorf("Encode(float64) should have errored")
+	}
+}
+
+func TestRawDictValue(t *testing.T) {
+	data := []byte("d8:announce3:url4:infod4:name1:xee")
+	raw, err := RawDictValue(data, "info")
+	if err != nil {
+		t.Fatalf("RawDictValue failed; %v", err)
+	}
+	if string(raw) != "d4:name1:xe" {
+		t.Errorf("RawDictValue = %q; expected %q", raw, "d4:name1:xe")
+	}
+	if _, err = RawDictValue(data, "missing"); err == nil {
+		t.Errorf("RawDictValue should have errored for a missing key")
+	}
+}
@@ -0,0 +1,186 @@
+package bencode
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/smquartz/errors"
+)
+
+// maxDepth is the maximum nesting of lists and dictionaries accepted by the
+// decoder, which protects against stack exhaustion on malicious input
+const maxDepth = 512
+
+// SyntaxError describes malformed bencoded data
+type SyntaxError struct {
+	// byte offset within the input at which the error was detected
+	Offset int
+	// description of the problem
+	Msg string
+}
+
+// Error implements the error interface
+func (e *SyntaxError) Error() string {
+	return "bencode: " + e.Msg + " at offset " + strconv.Itoa(e.Offset)
+}
+
+// Decode parses bencoded data.  Integers are decoded as int64, strings as
+// string, lists as []interface{} and dictionaries as map[string]interface{}.
+// The whole of data must consist of a single bencoded value.
+func Decode(data []byte) (interface{}, error) {
+	d := &decoder{data: data}
+	v, err := d.value(0)
+	if err != nil {
+		return nil, err
+	}
+	if d.pos != len(d.data) {
+		return nil, d.errorf("trailing data after value")
+	}
+	return v, nil
+}
+
+// DecodeReader parses bencoded data read from an io.Reader
+func DecodeReader(r io.Reader) (interface{}, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading bencoded data", 1)
+	}
+	return Decode(data)
+}
+
+// RawDictValue returns the raw bencoded bytes of the value stored under key in
+// the top level dictionary of data.  It is used where the exact original
+// encoding matters, such as when hashing the info dictionary of a torrent.
+func RawDictValue(data []byte, key string) ([]byte, error) {
+	d := &decoder{data: data}
+	if d.pos >= len(d.data) || d.data[d.pos] != 'd' {
+		return nil, d.errorf("expected dictionary")
+	}
+	d.pos++
+	for d.pos < len(d.data) && d.data[d.pos] != 'e' {
+		k, err := d.string()
+		if err != nil {
+			return nil, err
+		}
+		start := d.pos
+		if _, err = d.value(1); err != nil {
+			return nil, err
+		}
+		if k == key {
+			return d.data[start:d.pos], nil
+		}
+	}
+	if d.pos >= len(d.data) {
+		return nil, d.errorf("unterminated dictionary")
+	}
+	return nil, errors.Errorf("bencode: key %q not found in dictionary", key)
+}
+
+// decoder holds the state of a single decoding pass over bencoded data
+type decoder struct {
+	data []byte
+	pos  int
+}
+
+// errorf returns a SyntaxError at the current offset
+func (d *decoder) errorf(msg string) error {
+	return &SyntaxError{Offset: d.pos, Msg: msg}
+}
+
+// value decodes the value beginning at the current offset
+func (d *decoder) value(depth int) (interface{}, error) {
+	if depth > maxDepth {
+		return nil, d.errorf("maximum nesting depth exceeded")
+	}
+	if d.pos >= len(d.data) {
+		return nil, d.errorf("unexpected end of data")
+	}
+	switch c := d.data[d.pos]; {
+	case c == 'i':
+		return d.integer()
+	case c >= '0' && c <= '9':
+		return d.string()
+	case c == 'l':
+		d.pos++
+		list := []interface{}{}
+		for {
+			if d.pos >= len(d.data) {
+				return nil, d.errorf("unterminated list")
+			}
+			if d.data[d.pos] == 'e' {
+				d.pos++
+				return list, nil
+			}
+			v, err := d.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+	case c == 'd':
+		d.pos++
+		dict := map[string]interface{}{}
+		for {
+			if d.pos >= len(d.data) {
+				return nil, d.errorf("unterminated dictionary")
+			}
+			if d.data[d.pos] == 'e' {
+				d.pos++
+				return dict, nil
+			}
+			k, err := d.string()
+			if err != nil {
+				return nil, err
+			}
+			v, err := d.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			dict[k] = v
+		}
+	default:
+		return nil, d.errorf("invalid character " + strconv.QuoteRune(rune(c)))
+	}
+}
+
+// integer decodes an integer of the form i<digits>e
+func (d *decoder) integer() (int64, error) {
+	d.pos++
+	end := bytes.IndexByte(d.data[d.pos:], 'e')
+	if end < 0 {
+		return 0, d.errorf("unterminated integer")
+	}
+	digits := string(d.data[d.pos : d.pos+end])
+	if digits == "" || digits == "-" || digits == "-0" ||
+		(len(digits) > 1 && digits[0] == '0') ||
+		(len(digits) > 2 && digits[0] == '-' && digits[1] == '0') {
+		return 0, d.errorf("invalid integer " + strconv.Quote(digits))
+	}
+	n, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, d.errorf("invalid integer " + strconv.Quote(digits))
+	}
+	d.pos += end + 1
+	return n, nil
+}
+
+// string decodes a byte string of the form <length>:<bytes>
+func (d *decoder) string() (string, error) {
+	colon := bytes.IndexByte(d.data[d.pos:], ':')
+	if colon < 0 {
+		return "", d.errorf("expected string")
+	}
+	digits := string(d.data[d.pos : d.pos+colon])
+	n, err := strconv.ParseUint(digits, 10, 63)
+	if err != nil || (len(digits) > 1 && digits[0] == '0') {
+		return "", d.errorf("invalid string length " + strconv.Quote(digits))
+	}
+	start := d.pos + colon + 1
+	if n > uint64(len(d.data)-start) {
+		return "", d.errorf("string length exceeds data")
+	}
+	d.pos = start + int(n)
+	return string(d.data[start:d.pos]), nil
+}
@@ -0,0 +1,96 @@
+package bencode
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+
+	"github.com/smquartz/errors"
+)
+
+// Encode returns the bencoding of v.  Supported types are the integer types,
+// string, []byte, []interface{}, []string, map[string]interface{} and
+// RawMessage; dictionary keys are written in sorted order, as required by the
+// specification.
+func Encode(v interface{}) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	if err := encode(buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RawMessage is a value that is already bencoded, and is written as is
+type RawMessage []byte
+
+// encode writes the bencoding of v to buf
+func encode(buf *bytes.Buffer, v interface{}) error {
+	switch v := v.(type) {
+	case int:
+		encodeInt(buf, int64(v))
+	case int32:
+		encodeInt(buf, int64(v))
+	case int64:
+		encodeInt(buf, v)
+	case uint32:
+		encodeInt(buf, int64(v))
+	case uint64:
+		buf.WriteByte('i')
+		buf.WriteString(strconv.FormatUint(v, 10))
+		buf.WriteByte('e')
+	case bool:
+		if v {
+			encodeInt(buf, 1)
+		} else {
+			encodeInt(buf, 0)
+		}
+	case string:
+		buf.WriteString(strconv.Itoa(len(v)))
+		buf.WriteByte(':')
+		buf.WriteString(v)
+	case []byte:
+		buf.WriteString(strconv.Itoa(len(v)))
+		buf.WriteByte(':')
+		buf.Write(v)
+	case RawMessage:
+		buf.Write(v)
+	case []string:
+		buf.WriteByte('l')
+		for _, s := range v {
+			encode(buf, s)
+		}
+		buf.WriteByte('e')
+	case []interface{}:
+		buf.WriteByte('l')
+		for _, item := range v {
+			if err := encode(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('e')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		buf.WriteByte('d')
+		for _, k := range keys {
+			encode(buf, k)
+			if err := encode(buf, v[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('e')
+	default:
+		return errors.Errorf("bencode: unsupported type %T", v)
+	}
+	return nil
+}
+
+// encodeInt writes the bencoding of an integer to buf
+func encodeInt(buf *bytes.Buffer, n int64) {
+	buf.WriteByte('i')
+	buf.WriteString(strconv.FormatInt(n, 10))
+	buf.WriteByte('e')
+}
@@ -0,0 +1,161 @@
+package newznab
+
+import (
+	"context"
+	"encoding/xml"
+	"net/url"
+
+	"github.com/smquartz/errors"
+)
+
+// API function names for functions relating to accounts
+const (
+	FunctionUser     = "user"
+	FunctionRegister = "register"
+)
+
+// UserInfo describes an account on an indexer, and its usage of the indexer's
+// request limits
+type UserInfo struct {
+	// name of the account
+	Username string
+	// role of the account, e.g. "User" or "VIP"
+	Role string
+	// number of files the account has ever grabbed
+	Grabs int
+	// number of API requests made in the current period
+	APIRequests int
+	// number of downloads made in the current period
+	DownloadRequests int
+	// maximum number of API requests per period; zero if unknown
+	APILimit int
+	// maximum number of downloads per period; zero if unknown
+	DownloadLimit int
+}
+
+// APIRemaining returns the number of API requests remaining in the current
+// period, or -1 if the limit is unknown
+func (u UserInfo) APIRemaining() int { return remaining(u.APILimit, u.APIRequests) }
+
+// DownloadsRemaining returns the number of downloads remaining in the current
+// period, or -1 if the limit is unknown
+func (u UserInfo) DownloadsRemaining() int { return remaining(u.DownloadLimit, u.DownloadRequests) }
+
+// remaining returns the difference between limit and used, floored at zero,
+// or -1 if limit is zero
+func remaining(limit, used int) int {
+	if limit <= 0 {
+		return -1
+	}
+	if used >= limit {
+		return 0
+	}
+	return limit - used
+}
+
+// rawUserInfo describes the XML response format of the user function
+type rawUserInfo struct {
+	XMLName          xml.Name `xml:"user"`
+	Username         string   `xml:"username,attr"`
+	Role             string   `xml:"role,attr"`
+	Grabs            int      `xml:"grabs,attr"`
+	APIRequests      int      `xml:"apirequests,attr"`
+	DownloadRequests int      `xml:"downloadrequests,attr"`
+	APIMax           int      `xml:"apimax,attr"`
+	GrabMax          int      `xml:"grabmax,attr"`
+}
+
+// User fetches information about the account with the given username.  If the
+// indexer does not report the account's limits, those advertised in its
+// capabilities are used if they have already been fetched.  The Client's
+// Quota, if any, is updated with the reported usage.
+func (c *Client) User(username string) (UserInfo, error) {
+	return c.UserContext(context.Background(), username)
+}
+
+// UserContext is like User, but performs its request with the given
+// context
+func (c *Client) UserContext(ctx context.Context, username string) (UserInfo, error) {
+	values := url.Values{}
+	if username != "" {
+		values.Set("username", username)
+	}
+	data, err := c.callAPI(ctx, FunctionUser, values)
+	if err != nil {
+		return UserInfo{}, err
+	}
+
+	raw := new(rawUserInfo)
+	if err = xml.Unmarshal(data, raw); err != nil {
+		return UserInfo{}, errors.Wrapf(err, "error unmarshalling user information", 1)
+	}
+	info := UserInfo{
+		Username:         raw.Username,
+		Role:             raw.Role,
+		Grabs:            raw.Grabs,
+		APIRequests:      raw.APIRequests,
+		DownloadRequests: raw.DownloadRequests,
+		APILimit:         raw.APIMax,
+		DownloadLimit:    raw.GrabMax,
+	}
+
+	c.capabilitiesMu.Lock()
+	if caps := c.capabilities; caps != nil {
+		if info.APILimit == 0 {
+			info.APILimit = caps.Limits.APIMax
+		}
+		if info.DownloadLimit == 0 {
+			info.DownloadLimit = caps.Limits.GrabMax
+		}
+	}
+	c.capabilitiesMu.Unlock()
+
+	if c.Quota != nil {
+		c.Quota.sync(RequestAPI, info.APIRequests, info.APILimit)
+		c.Quota.sync(RequestDownload, info.DownloadRequests, info.DownloadLimit)
+	}
+	return info, nil
+}
+
+// Account describes the credentials of a newly registered account
+type Account struct {
+	// name of the account
+	Username string
+	// password of the account
+	Password string
+	// API key of the account
+	APIKey string
+}
+
+// rawAccount describes the XML response format of the register function
+type rawAccount struct {
+	XMLName  xml.Name `xml:"register"`
+	Username string   `xml:"username,attr"`
+	Password string   `xml:"password,attr"`
+	APIKey   string   `xml:"apikey,attr"`
+}
+
+// Register registers a new account on the indexer with the given email
+// address.  Registration failures are returned as an *APIError, for which
+// ErrorCode.IsRegistrationError returns true.
+func (c *Client) Register(email string) (Account, error) {
+	return c.RegisterContext(context.Background(), email)
+}
+
+// RegisterContext is like Register, but performs its request with the given
+// context
+func (c *Client) RegisterContext(ctx context.Context, email string) (Account, error) {
+	data, err := c.getURLResponseBody(ctx, RequestAPI, c.buildURL(ModePathAPI, url.Values{
+		"t":     []string{FunctionRegister},
+		"email": []string{email},
+	}))
+	if err != nil {
+		return Account{}, err
+	}
+
+	raw := new(rawAccount)
+	if err = xml.Unmarshal(data, raw); err != nil {
+		return Account{}, errors.Wrapf(err, "error unmarshalling registration", 1)
+	}
+	return Account{Username: raw.Username, Password: raw.Password, APIKey: raw.APIKey}, nil
+}
@@ -0,0 +1,61 @@
+package newznab
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestUser(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+	client.Quota = new(Quota)
+	client.capabilities = &Capabilities{Limits: Limits{APIMax: 100, GrabMax: 10}}
+
+	info, err := client.UserContext(context.Background(), "tester")
+	if err != nil {
+		t.Fatalf("User failed; %v", err)
+	}
+	expected := UserInfo{
+		Username:         "tester",
+		Role:             "User",
+		Grabs:            1337,
+		APIRequests:      42,
+		DownloadRequests: 7,
+		APILimit:         100,
+		DownloadLimit:    10,
+	}
+	if info != expected {
+		t.Errorf("Wrong user information; got %+v expected %+v", info, expected)
+	}
+	if info.APIRemaining() != 58 || info.DownloadsRemaining() != 3 {
+		t.Errorf("Wrong remaining requests; got %d, %d", info.APIRemaining(), info.DownloadsRemaining())
+	}
+	if client.Quota.Remaining(RequestAPI) != 58 || client.Quota.Remaining(RequestDownload) != 3 {
+		t.Errorf("Quota not updated; got %d, %d remaining", client.Quota.Remaining(RequestAPI), client.Quota.Remaining(RequestDownload))
+	}
+
+	if remaining := (UserInfo{APIRequests: 5}).APIRemaining(); remaining != -1 {
+		t.Errorf("Remaining requests should be unknown without a limit; got %d", remaining)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+
+	account, err := client.Register("tester@example.com")
+	if err != nil {
+		t.Fatalf("Register failed; %v", err)
+	}
+	expected := Account{Username: "tester", Password: "c9a1f0e3", APIKey: "0123456789abcdef0123456789abcdef"}
+	if account != expected {
+		t.Errorf("Wrong account; got %+v expected %+v", account, expected)
+	}
+
+	_, err = client.Register("taken@example.com")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != ErrorCodeEmailTaken || !apiErr.Code.IsRegistrationError() {
+		t.Errorf("Registering a taken email address should return ErrorCodeEmailTaken; got %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package newznab
+
+import (
+	"sync"
+	"time"
+)
+
+// XML namespaces of the attr elements in newznab and torznab feeds
+const (
+	NamespaceNewznab = "http://www.newznab.com/DTD/2010/feeds/attributes/"
+	NamespaceTorznab = "http://torznab.com/schemas/2015/feed"
+)
+
+// Attribute is a single newznab or torznab attribute of an item
+type Attribute struct {
+	// XML namespace of the attr element, e.g. NamespaceTorznab
+	Namespace string
+	// name of the attribute, e.g. seeders
+	Name string
+	// value of the attribute
+	Value string
+
+	// parser for dates in the feed the attribute was found in
+	dates *DateParser
+}
+
+// Time parses the value of the attribute as a date, with the DateParser of
+// the Client that fetched it, or the DefaultDateLayouts otherwise
+func (a Attribute) Time() (time.Time, error) {
+	return a.dates.Parse(a.Value)
+}
+
+// AttributeHandler sets the fields of an Entry corresponding to an attribute.
+// Returning an error fails the parsing of the whole feed, so handlers should
+// only do so for values that make the Entry unusable.
+type AttributeHandler func(e *Entry, attr Attribute) error
+
+// attributeKey identifies a handler within an AttributeRegistry
+type attributeKey struct {
+	namespace string
+	name      string
+}
+
+// AttributeRegistry maps attribute names to the handlers that parse them.
+// Handlers may be registered for a specific namespace, or for any namespace;
+// a namespace specific handler takes precedence.  Attributes without a
+// handler are ignored, but remain available in Entry.Attributes.
+type AttributeRegistry struct {
+	mu       sync.RWMutex
+	handlers map[attributeKey]AttributeHandler
+}
+
+// NewAttributeRegistry returns an AttributeRegistry with no handlers
+func NewAttributeRegistry() *AttributeRegistry {
+	return &AttributeRegistry{handlers: make(map[attributeKey]AttributeHandler)}
+}
+
+// DefaultAttributeRegistry holds the handlers for the standard newznab and
+// torznab attributes, and is used by Clients with no AttributeRegistry of
+// their own.  Handlers registered on it apply to every such Client.
+var DefaultAttributeRegistry = newDefaultAttributeRegistry()
+
+// Register sets the handler for the named attribute in the given namespace,
+// replacing any existing handler.  An empty namespace registers the handler
+// for every namespace.
+func (r *AttributeRegistry) Register(namespace, name string, h AttributeHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[attributeKey{namespace, name}] = h
+}
+
+// Unregister removes the handler for the named attribute in the given
+// namespace
+func (r *AttributeRegistry) Unregister(namespace, name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.handlers, attributeKey{namespace, name})
+}
+
+// Handler returns the handler for the named attribute in the given
+// namespace, falling back to the handler registered for every namespace
+func (r *AttributeRegistry) Handler(namespace, name string) (AttributeHandler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if h, ok := r.handlers[attributeKey{namespace, name}]; ok {
+		return h, true
+	}
+	h, ok := r.handlers[attributeKey{"", name}]
+	return h, ok
+}
+
+// Clone returns a copy of the registry, which may be modified without
+// affecting the original; e.g. to customise the default handlers for a
+// single Client
+func (r *AttributeRegistry) Clone() *AttributeRegistry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	clone := NewAttributeRegistry()
+	for k, h := range r.handlers {
+		clone.handlers[k] = h
+	}
+	return clone
+}
+
+// newDefaultAttributeRegistry returns a registry holding the handlers for the
+// standard newznab and torznab attributes
+func newDefaultAttributeRegistry() *AttributeRegistry {
+	r := NewAttributeRegistry()
+	register := func(h AttributeHandler, names ...string) {
+		for _, name := range names {
+			r.Register("", name, h)
+		}
+	}
+	register((*Entry).fromRawGeneralAttribute, "category", "genre", "info")
+	register((*Entry).fromRawMetaAttribute, "guid", "comments", "grabs", "usenetdate")
+	register((*Entry).fromRawContentAttribute,
+		"rating", "tvtitle", "episode", "season", "rageid", "tvdbid", "tvairdate",
+		"imdb", "imdbid", "imdbtitle", "imdbyear", "imdbscore", "coverurl",
+		"artist", "album", "label", "track", "year", "publisher",
+		"booktitle", "author", "publishdate", "pages", "isbn",
+		"tvmazeid", "tmdbid", "traktid", "doubanid", "anidbid")
+	register((*Entry).fromRawMediaAttribute,
+		"video", "audio", "resolution", "framerate", "language", "subs",
+		"imdbtagline", "imdbplot", "imdbdirector", "imdbactors", "backdropcoverurl")
+	register((*Entry).fromRawTorrentAttribute,
+		"seeders", "leechers", "peers", "files", "infohash", "magneturl",
+		"downloadvolumefactor", "uploadvolumefactor", "minimumratio",
+		"minimumseedtime", "tag")
+	// size describes the torrent contents in torznab feeds, but the release
+	// in newznab feeds, which may not be a torrent at all
+	register((*Entry).fromRawSizeAttribute, "size")
+	r.Register(NamespaceTorznab, "size", (*Entry).fromRawTorrentAttribute)
+	return r
+}
+
+// attributeRegistry returns the AttributeRegistry used by the Client
+func (c *Client) attributeRegistry() *AttributeRegistry {
+	if c.AttributeRegistry != nil {
+		return c.AttributeRegistry
+	}
+	return DefaultAttributeRegistry
+}
@@ -0,0 +1,139 @@
+package newznab
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+func TestAttributeRegistryHandler(t *testing.T) {
+	var called string
+	r := NewAttributeRegistry()
+	r.Register("", "score", func(e *Entry, attr Attribute) error {
+		called = "any"
+		return nil
+	})
+	r.Register(NamespaceTorznab, "score", func(e *Entry, attr Attribute) error {
+		called = "torznab"
+		return nil
+	})
+
+	for namespace, expected := range map[string]string{NamespaceTorznab: "torznab", NamespaceNewznab: "any", "": "any"} {
+		h, ok := r.Handler(namespace, "score")
+		if !ok {
+			t.Fatalf("Handler for %q not found", namespace)
+		}
+		h(nil, Attribute{})
+		if called != expected {
+			t.Errorf("Wrong handler for %q; got %v expected %v", namespace, called, expected)
+		}
+	}
+	if _, ok := r.Handler(NamespaceNewznab, "sc"); ok {
+		t.Errorf("Attribute names should not match by substring")
+	}
+
+	clone := r.Clone()
+	clone.Unregister("", "score")
+	if _, ok := clone.Handler(NamespaceNewznab, "score"); ok {
+		t.Errorf("Handler should have been unregistered from the clone")
+	}
+	if _, ok := r.Handler(NamespaceNewznab, "score"); !ok {
+		t.Errorf("Unregistering from a clone should not affect the original")
+	}
+}
+
+func TestSearchCustomAttributeHandler(t *testing.T) {
+	ts := newMockServer()
+	defer ts.Close()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse mock server URL")
+	}
+	scores := make(map[EntryID]int)
+	registry := DefaultAttributeRegistry.Clone()
+	registry.Register(NamespaceNewznab, "x-indexer-score", func(e *Entry, attr Attribute) error {
+		score, err := strconv.Atoi(attr.Value)
+		if err != nil {
+			return err
+		}
+		scores[e.Meta.ID] = score
+		return nil
+	})
+	client := &Client{HTTPClient: &http.Client{}, BaseURL: u, APIKey: "gibberish", AttributeRegistry: registry}
+
+	// the fixture includes attributes named id, e and at, which must not be
+	// mistaken for guid, episode or other standard attributes
+	result, err := client.Do(context.Background(), SearchRequest{
+		Function:   FunctionSearch,
+		Query:      "Extensions",
+		Categories: []Category{CategoryTVAll},
+	})
+	if err != nil {
+		t.Fatalf("Failed to search mock indexer; %v", err)
+	}
+	if len(result.Entries) != 1 {
+		t.Fatalf("Wrong number of results; got %d expected %d", len(result.Entries), 1)
+	}
+	entry := result.Entries[0]
+	if scores[entry.Meta.ID] != 87 {
+		t.Errorf("Custom handler not called; got %v", scores)
+	}
+	if entry.Meta.ID != EntryID("4f8e2a6c0b1d3e5f7a9c2b4d6e8f0a1c") || entry.Content != nil {
+		t.Errorf("Unknown attributes should not be routed to standard handlers; got %v, %+v", entry.Meta.ID, entry.Content)
+	}
+	if entry.File != nil {
+		t.Errorf("A newznab size should not make the entry a torrent; got %T", entry.File)
+	}
+	if _, ok := DefaultAttributeRegistry.Handler(NamespaceNewznab, "x-indexer-score"); ok {
+		t.Errorf("Registering on a clone should not affect the default registry")
+	}
+}
+
+func TestSearchMixedContentAttributes(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+
+	result, err := client.Do(context.Background(), SearchRequest{
+		Query:      "Mixed",
+		Categories: []Category{CategoryTVAll},
+	})
+	if err != nil {
+		t.Fatalf("Attributes of another content type should not fail the search; %v", err)
+	}
+	if len(result.Entries) != 2 {
+		t.Fatalf("Wrong number of results; got %d expected %d", len(result.Entries), 2)
+	}
+
+	cases := []struct {
+		ignored []string
+	}{
+		{[]string{"imdbtitle", "imdbscore", "artist"}},
+		{[]string{"season", "rating"}},
+	}
+	for k, c := range cases {
+		entry := result.Entries[k]
+		warned := map[string]bool{}
+		for _, warning := range entry.Meta.Warnings {
+			warned[warning.Field] = true
+		}
+		for _, name := range c.ignored {
+			if !warned[name] {
+				t.Errorf("Entry %d should have a warning for %v; got %v", k, name, entry.Meta.Warnings)
+			}
+		}
+	}
+
+	tv, ok := result.Entries[0].Content.(*TV)
+	if !ok || tv.TVDBID != 176941 || tv.EpisodeSpec.String() != "S02E01" {
+		t.Errorf("TV attributes should still be applied; got %+v", result.Entries[0].Content)
+	}
+	if result.Entries[0].Media.Year != 2012 {
+		t.Errorf("Shared year should still be applied; got %d", result.Entries[0].Media.Year)
+	}
+	book, ok := result.Entries[1].Content.(*Book)
+	if !ok || book.Author != "Arthur Conan Doyle" {
+		t.Errorf("Book attributes should still be applied; got %+v", result.Entries[1].Content)
+	}
+}
@@ -0,0 +1,413 @@
+package newznab
+
+import (
+	"context"
+	"encoding/xml"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/smquartz/errors"
+)
+
+// Search function names, as used for the t parameter of the newznab API
+const (
+	FunctionSearch      = "search"
+	FunctionTVSearch    = "tvsearch"
+	FunctionMovieSearch = "movie"
+	FunctionMusicSearch = "music"
+	FunctionBookSearch  = "book"
+)
+
+// Capabilities describes the information an indexer advertises about itself
+// through the caps function of the newznab API
+type Capabilities struct {
+	// information about the indexer software and its operator
+	Server ServerInfo
+	// limits on the number of results returned per request
+	Limits Limits
+	// whether registration is available and open on the indexer
+	Registration Registration
+	// search functions the indexer supports, and their parameters
+	Searching Searching
+	// category tree the indexer uses
+	Categories []CapabilityCategory
+	// usenet groups the indexer indexes
+	Groups []Group
+	// genres the indexer uses to classify content
+	Genres []Genre
+}
+
+// ServerInfo describes the indexer software and its operator
+type ServerInfo struct {
+	// version of the indexer application
+	AppVersion string
+	// version of the newznab API spoken by the indexer
+	Version string
+	// title of the indexer
+	Title string
+	// strapline of the indexer
+	Strapline string
+	// contact email address for the indexer
+	Email string
+	// URL of the indexer's website
+	URL *url.URL
+	// URL of the indexer's logo
+	Image *url.URL
+}
+
+// Limits describes the limits on the number of results returned per request,
+// and on the number of requests per day if the indexer advertises them
+type Limits struct {
+	// maximum number of results the indexer will return for a single request
+	Max int
+	// number of results returned if no limit is specified
+	Default int
+	// maximum number of API hits per day; zero if not advertised
+	APIMax int
+	// maximum number of downloads per day; zero if not advertised
+	GrabMax int
+}
+
+// Registration describes whether new users may register with the indexer
+type Registration struct {
+	// whether registration is supported by the indexer at all
+	Available bool
+	// whether registration is currently open
+	Open bool
+}
+
+// Searching describes the search functions supported by an indexer
+type Searching struct {
+	// generic query based search (t=search)
+	Search SearchMode
+	// TV search (t=tvsearch)
+	TVSearch SearchMode
+	// movie search (t=movie)
+	MovieSearch SearchMode
+	// music search (t=music)
+	AudioSearch SearchMode
+	// book search (t=book)
+	BookSearch SearchMode
+}
+
+// Mode returns the SearchMode corresponding to the given API function name,
+// such as FunctionTVSearch.  The boolean return value is false if the function
+// is not a known search function.
+func (s Searching) Mode(function string) (SearchMode, bool) {
+	switch function {
+	case FunctionSearch:
+		return s.Search, true
+	case FunctionTVSearch:
+		return s.TVSearch, true
+	case FunctionMovieSearch:
+		return s.MovieSearch, true
+	case FunctionMusicSearch:
+		return s.AudioSearch, true
+	case FunctionBookSearch:
+		return s.BookSearch, true
+	default:
+		return SearchMode{}, false
+	}
+}
+
+// SearchMode describes the availability of a single search function, and the
+// parameters it supports
+type SearchMode struct {
+	// whether the search function is available
+	Available bool
+	// parameters the search function supports, e.g. q, tvdbid, season
+	SupportedParams []string
+}
+
+// SupportsParam returns whether the given parameter is supported by the
+// search mode
+func (m SearchMode) SupportsParam(param string) bool {
+	for _, p := range m.SupportedParams {
+		if p == param {
+			return true
+		}
+	}
+	return false
+}
+
+// Supports returns whether the given search function is available, and
+// supports all of the given parameters
+func (c Capabilities) Supports(function string, params ...string) bool {
+	mode, ok := c.Searching.Mode(function)
+	if !ok || !mode.Available {
+		return false
+	}
+	for _, p := range params {
+		if !mode.SupportsParam(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// CapabilityCategory describes a category, and its subcategories, as
+// advertised by an indexer
+type CapabilityCategory struct {
+	// ID of the category
+	ID Category
+	// name of the category
+	Name string
+	// description of the category
+	Description string
+	// subcategories of the category
+	Subcategories []CapabilityCategory
+}
+
+// Group describes a usenet group indexed by an indexer
+type Group struct {
+	// ID of the group on the indexer
+	ID int
+	// name of the group, e.g. alt.binaries.teevee
+	Name string
+	// description of the group
+	Description string
+	// time the group was last updated by the indexer
+	LastUpdate time.Time
+}
+
+// Genre describes a genre used by an indexer to classify content
+type Genre struct {
+	// ID of the genre on the indexer
+	ID int
+	// category the genre applies to
+	Category Category
+	// name of the genre
+	Name string
+}
+
+// Capabilities returns the capabilities advertised by the indexer.  The
+// capabilities are fetched once and cached on the Client; subsequent calls
+// return the cached value.  Use RefreshCapabilities to fetch them again.
+func (c *Client) Capabilities() (Capabilities, error) {
+	return c.CapabilitiesContext(context.Background())
+}
+
+// CapabilitiesContext returns the capabilities advertised by the indexer,
+// fetching them with the given context if they are not yet cached on the
+// Client.  The cache is not locked while fetching, so concurrent callers may
+// each fetch the capabilities; the first result to arrive is cached and
+// returned to all of them.  The returned value is a copy, and may be modified
+// without affecting the cache.
+func (c *Client) CapabilitiesContext(ctx context.Context) (Capabilities, error) {
+	c.capabilitiesMu.Lock()
+	cached := c.capabilities
+	c.capabilitiesMu.Unlock()
+	if cached != nil {
+		return cached.clone(), nil
+	}
+
+	caps, err := c.fetchCapabilities(ctx)
+	if err != nil {
+		return Capabilities{}, err
+	}
+	return c.storeCapabilities(caps, false), nil
+}
+
+// RefreshCapabilities fetches the capabilities advertised by the indexer,
+// replacing any value cached on the Client
+func (c *Client) RefreshCapabilities() (Capabilities, error) {
+	return c.RefreshCapabilitiesContext(context.Background())
+}
+
+// RefreshCapabilitiesContext fetches the capabilities advertised by the
+// indexer with the given context, replacing any value cached on the Client
+func (c *Client) RefreshCapabilitiesContext(ctx context.Context) (Capabilities, error) {
+	caps, err := c.fetchCapabilities(ctx)
+	if err != nil {
+		return Capabilities{}, err
+	}
+	return c.storeCapabilities(caps, true), nil
+}
+
+// fetchCapabilities requests and parses the caps document
+func (c *Client) fetchCapabilities(ctx context.Context) (Capabilities, error) {
+	values := url.Values{"t": []string{"caps"}}
+	if c.APIKey != "" {
+		values.Set("apikey", c.APIKey)
+	}
+	data, err := c.getURLResponseBody(ctx, RequestAPI, c.buildURL(ModePathAPI, values))
+	if err != nil {
+		return Capabilities{}, err
+	}
+
+	raw := new(rawCapabilities)
+	err = xml.Unmarshal(data, raw)
+	if err != nil {
+		return Capabilities{}, errors.Wrapf(err, "error unmarshalling XML response into rawCapabilities", 1)
+	}
+	return capabilitiesFromRaw(*raw), nil
+}
+
+// storeCapabilities caches caps on the Client, and applies the limits they
+// advertise to the Client's Quota.  Unless replace is true, a value cached by
+// a concurrent fetch is kept instead.  A copy of the cached value is returned.
+func (c *Client) storeCapabilities(caps Capabilities, replace bool) Capabilities {
+	c.capabilitiesMu.Lock()
+	if c.capabilities != nil && !replace {
+		caps = *c.capabilities
+	} else {
+		c.capabilities = &caps
+	}
+	c.capabilitiesMu.Unlock()
+
+	if c.Quota != nil {
+		if caps.Limits.APIMax > 0 {
+			c.Quota.SetLimit(RequestAPI, caps.Limits.APIMax)
+		}
+		if caps.Limits.GrabMax > 0 {
+			c.Quota.SetLimit(RequestDownload, caps.Limits.GrabMax)
+		}
+	}
+	return caps.clone()
+}
+
+// clone returns a deep copy of c, sharing no slices or pointers with it
+func (c Capabilities) clone() Capabilities {
+	if c.Server.URL != nil {
+		u := *c.Server.URL
+		c.Server.URL = &u
+	}
+	if c.Server.Image != nil {
+		u := *c.Server.Image
+		c.Server.Image = &u
+	}
+	c.Searching.Search = c.Searching.Search.clone()
+	c.Searching.TVSearch = c.Searching.TVSearch.clone()
+	c.Searching.MovieSearch = c.Searching.MovieSearch.clone()
+	c.Searching.AudioSearch = c.Searching.AudioSearch.clone()
+	c.Searching.BookSearch = c.Searching.BookSearch.clone()
+	c.Categories = cloneCapabilityCategories(c.Categories)
+	if c.Groups != nil {
+		c.Groups = append([]Group(nil), c.Groups...)
+	}
+	if c.Genres != nil {
+		c.Genres = append([]Genre(nil), c.Genres...)
+	}
+	return c
+}
+
+// clone returns a copy of m that does not share its SupportedParams
+func (m SearchMode) clone() SearchMode {
+	if m.SupportedParams != nil {
+		m.SupportedParams = append([]string(nil), m.SupportedParams...)
+	}
+	return m
+}
+
+// cloneCapabilityCategories returns a deep copy of cats, including their
+// subcategories
+func cloneCapabilityCategories(cats []CapabilityCategory) []CapabilityCategory {
+	if cats == nil {
+		return nil
+	}
+	out := make([]CapabilityCategory, len(cats))
+	for i, cat := range cats {
+		cat.Subcategories = cloneCapabilityCategories(cat.Subcategories)
+		out[i] = cat
+	}
+	return out
+}
+
+// capabilitiesFromRaw converts a rawCapabilities into Capabilities
+func capabilitiesFromRaw(raw rawCapabilities) (caps Capabilities) {
+	caps.Server = ServerInfo{
+		AppVersion: raw.Server.AppVersion,
+		Version:    raw.Server.Version,
+		Title:      raw.Server.Title,
+		Strapline:  raw.Server.Strapline,
+		Email:      raw.Server.Email,
+	}
+	if u, err := url.Parse(raw.Server.URL); err == nil && raw.Server.URL != "" {
+		caps.Server.URL = u
+	}
+	if u, err := url.Parse(raw.Server.Image); err == nil && raw.Server.Image != "" {
+		caps.Server.Image = u
+	}
+
+	caps.Limits = Limits{
+		Max:     raw.Limits.Max,
+		Default: raw.Limits.Default,
+		APIMax:  raw.Limits.APIMax,
+		GrabMax: raw.Limits.GrabMax,
+	}
+	caps.Registration = Registration{
+		Available: parseYesNo(raw.Registration.Available),
+		Open:      parseYesNo(raw.Registration.Open),
+	}
+
+	caps.Searching = Searching{
+		Search:      searchModeFromRaw(raw.Searching.Search),
+		TVSearch:    searchModeFromRaw(raw.Searching.TVSearch),
+		MovieSearch: searchModeFromRaw(raw.Searching.MovieSearch),
+		AudioSearch: searchModeFromRaw(raw.Searching.AudioSearch),
+		BookSearch:  searchModeFromRaw(raw.Searching.BookSearch),
+	}
+
+	for _, rawCat := range raw.Categories {
+		caps.Categories = append(caps.Categories, capabilityCategoryFromRaw(rawCat))
+	}
+
+	for _, rawGroup := range raw.Groups {
+		group := Group{
+			ID:          rawGroup.ID,
+			Name:        rawGroup.Name,
+			Description: rawGroup.Description,
+		}
+		if lastUpdate, err := parseDate(rawGroup.LastUpdate); err == nil {
+			group.LastUpdate = lastUpdate
+		}
+		caps.Groups = append(caps.Groups, group)
+	}
+
+	for _, rawGenre := range raw.Genres {
+		caps.Genres = append(caps.Genres, Genre{
+			ID:       rawGenre.ID,
+			Category: Category(rawGenre.CategoryID),
+			Name:     rawGenre.Name,
+		})
+	}
+
+	return caps
+}
+
+// searchModeFromRaw converts a rawSearchMode into a SearchMode
+func searchModeFromRaw(raw rawSearchMode) (mode SearchMode) {
+	mode.Available = parseYesNo(raw.Available)
+	for _, p := range strings.Split(raw.SupportedParams, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			mode.SupportedParams = append(mode.SupportedParams, p)
+		}
+	}
+	return mode
+}
+
+// capabilityCategoryFromRaw converts a rawCapabilityCategory, including its
+// subcategories, into a CapabilityCategory
+func capabilityCategoryFromRaw(raw rawCapabilityCategory) CapabilityCategory {
+	cat := CapabilityCategory{
+		ID:          Category(raw.ID),
+		Name:        raw.Name,
+		Description: raw.Description,
+	}
+	for _, sub := range raw.Subcategories {
+		cat.Subcategories = append(cat.Subcategories, capabilityCategoryFromRaw(sub))
+	}
+	return cat
+}
+
+// parseYesNo parses the yes/no strings used for boolean values in caps
+// responses
+func parseYesNo(s string) bool {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "yes", "true", "1":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,76 @@
+package newznab
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestCapabilities(t *testing.T) {
+	ts := newMockServer()
+	defer ts.Close()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse mock server URL")
+	}
+	client := &Client{HTTPClient: &http.Client{}, BaseURL: u, APIKey: "gibberish"}
+
+	caps, err := client.Capabilities()
+	if err != nil {
+		t.Fatalf("Failed to fetch capabilities; %v", err)
+	}
+
+	if caps.Server.Title != "Newznab" {
+		t.Errorf("Wrong server title; got %v expected %v", caps.Server.Title, "Newznab")
+	}
+	if caps.Limits.Max != 100 || caps.Limits.Default != 50 {
+		t.Errorf("Wrong limits; got %+v", caps.Limits)
+	}
+	if !caps.Registration.Available || caps.Registration.Open {
+		t.Errorf("Wrong registration; got %+v", caps.Registration)
+	}
+	if !caps.Supports(FunctionTVSearch, "tvdbid", "season", "ep") {
+		t.Errorf("tvsearch with tvdbid should be supported")
+	}
+	if caps.Supports(FunctionTVSearch, "imdbid") {
+		t.Errorf("tvsearch with imdbid should not be supported")
+	}
+	if !caps.Supports(FunctionMusicSearch, "artist", "album") {
+		t.Errorf("music search with artist and album should be supported")
+	}
+	if caps.Supports(FunctionMusicSearch, "label") {
+		t.Errorf("music search with label should not be supported")
+	}
+	if len(caps.Categories) != 2 {
+		t.Fatalf("Wrong number of categories; got %d expected %d", len(caps.Categories), 2)
+	}
+	if tv := caps.Categories[1]; tv.ID != CategoryTVAll || len(tv.Subcategories) != 5 {
+		t.Errorf("Wrong TV category; got %+v", tv)
+	}
+	if len(caps.Groups) != 2 || caps.Groups[0].LastUpdate.Year() != 2017 {
+		t.Errorf("Wrong groups; got %+v", caps.Groups)
+	}
+	if len(caps.Genres) != 2 || caps.Genres[0].Category != CategoryTVAll {
+		t.Errorf("Wrong genres; got %+v", caps.Genres)
+	}
+
+	// modifying the returned value should not modify the cache
+	caps.Categories[1].Subcategories[0].Name = "Modified"
+	caps.Searching.TVSearch.SupportedParams[2] = "modified"
+	caps.Genres = caps.Genres[:0]
+
+	// the capabilities should now be cached, so closing the server should not
+	// matter
+	ts.Close()
+	cached, err := client.CapabilitiesContext(context.Background())
+	if err != nil {
+		t.Fatalf("Capabilities should have been cached; %v", err)
+	}
+	if cached.Categories[1].Subcategories[0].Name == "Modified" || !cached.Supports(FunctionTVSearch, "tvdbid", "season", "ep") || len(cached.Genres) != 2 {
+		t.Errorf("Modifying the returned capabilities modified the cache; got %+v", cached)
+	}
+	if _, err := client.RefreshCapabilitiesContext(context.Background()); err == nil {
+		t.Errorf("RefreshCapabilities should have errored against a closed server")
+	}
+}
@@ -0,0 +1,36 @@
+package newznab
+
+import (
+	"context"
+	"net/url"
+)
+
+// API function names for functions relating to the account's cart
+const (
+	FunctionCartAdd    = "cartadd"
+	FunctionCartDelete = "cartdel"
+)
+
+// CartAdd adds the entry with the given ID to the account's cart
+func (c *Client) CartAdd(id EntryID) error {
+	return c.CartAddContext(context.Background(), id)
+}
+
+// CartAddContext is like CartAdd, but performs its request with the given
+// context
+func (c *Client) CartAddContext(ctx context.Context, id EntryID) error {
+	_, err := c.callAPI(ctx, FunctionCartAdd, url.Values{"id": []string{id.APIValue()}})
+	return err
+}
+
+// CartDelete removes the entry with the given ID from the account's cart
+func (c *Client) CartDelete(id EntryID) error {
+	return c.CartDeleteContext(context.Background(), id)
+}
+
+// CartDeleteContext is like CartDelete, but performs its request with the given
+// context
+func (c *Client) CartDeleteContext(ctx context.Context, id EntryID) error {
+	_, err := c.callAPI(ctx, FunctionCartDelete, url.Values{"id": []string{id.APIValue()}})
+	return err
+}
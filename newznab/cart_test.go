@@ -0,0 +1,22 @@
+package newznab
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCart(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+	id := EntryID("85ae3c25b68a6f1870bc7f732b939045")
+
+	if err := client.CartAdd(id); err != nil {
+		t.Errorf("CartAdd failed; %v", err)
+	}
+	if err := client.CartDeleteContext(context.Background(), id); err != nil {
+		t.Errorf("CartDelete failed; %v", err)
+	}
+	if err := client.CartAdd(EntryID("ffffffffffffffffffffffffffffffff")); err == nil {
+		t.Errorf("CartAdd of an unknown entry should fail")
+	}
+}
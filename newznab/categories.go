@@ -1,23 +1,53 @@
 package newznab
 
+import (
+	"strconv"
+	"strings"
+
+	"github.com/smquartz/errors"
+)
+
 // Category describes a newznab category
 type Category int
 
+// CategoryCustomMin is the lowest category ID reserved for indexer specific
+// custom categories; categories at or above it are not part of the standard
+// newznab category table
+const CategoryCustomMin Category = 100000
+
 // Newznab category constants
 const (
-	// TV Categories
-	// CategoryTVAll is for all shows
-	CategoryTVAll Category = 5000
-	// CategoryTVForeign is for foreign shows
-	CategoryTVForeign Category = 5020
-	// CategoryTVSD is for standard-definition shows
-	CategoryTVSD Category = 5030
-	// CategoryTVHD is for high-definition shows
-	CategoryTVHD Category = 5040
-	// CategoryTVOther is for other shows
-	CategoryTVOther Category = 5050
-	// CategoryTVSport is for sports shows
-	CategoryTVSport Category = 5060
+	// Console categories
+	// CategoryConsoleAll is for all console content
+	CategoryConsoleAll Category = 1000
+	// CategoryConsoleNDS is for Nintendo DS games
+	CategoryConsoleNDS Category = 1010
+	// CategoryConsolePSP is for PlayStation Portable games
+	CategoryConsolePSP Category = 1020
+	// CategoryConsoleWii is for Wii games
+	CategoryConsoleWii Category = 1030
+	// CategoryConsoleXbox is for Xbox games
+	CategoryConsoleXbox Category = 1040
+	// CategoryConsoleXbox360 is for Xbox 360 games
+	CategoryConsoleXbox360 Category = 1050
+	// CategoryConsoleWiiware is for WiiWare games
+	CategoryConsoleWiiware Category = 1060
+	// CategoryConsoleXbox360DLC is for Xbox 360 downloadable content
+	CategoryConsoleXbox360DLC Category = 1070
+	// CategoryConsolePS3 is for PlayStation 3 games
+	CategoryConsolePS3 Category = 1080
+	// CategoryConsoleOther is for other console content
+	CategoryConsoleOther Category = 1090
+	// CategoryConsole3DS is for Nintendo 3DS games
+	CategoryConsole3DS Category = 1110
+	// CategoryConsolePSVita is for PlayStation Vita games
+	CategoryConsolePSVita Category = 1120
+	// CategoryConsoleWiiU is for Wii U games
+	CategoryConsoleWiiU Category = 1130
+	// CategoryConsoleXboxOne is for Xbox One games
+	CategoryConsoleXboxOne Category = 1140
+	// CategoryConsolePS4 is for PlayStation 4 games
+	CategoryConsolePS4 Category = 1180
 
 	// Movie categories
 	// CategoryMovieAll is for all movies
@@ -30,8 +60,257 @@ const (
 	CategoryMovieSD Category = 2030
 	// CategoryMovieHD is for high-definition movies
 	CategoryMovieHD Category = 2040
+	// CategoryMovieUHD is for ultra-high-definition movies
+	CategoryMovieUHD Category = 2045
 	// CategoryMovieBluRay is for blu-ray movies
 	CategoryMovieBluRay Category = 2050
 	// CategoryMovie3D is for 3-D movies
 	CategoryMovie3D Category = 2060
+	// CategoryMovieDVD is for DVD movies
+	CategoryMovieDVD Category = 2070
+	// CategoryMovieWebDL is for movies downloaded from streaming services
+	CategoryMovieWebDL Category = 2080
+
+	// Audio categories
+	// CategoryAudioAll is for all audio
+	CategoryAudioAll Category = 3000
+	// CategoryAudioMP3 is for MP3 audio
+	CategoryAudioMP3 Category = 3010
+	// CategoryAudioVideo is for music videos
+	CategoryAudioVideo Category = 3020
+	// CategoryAudioAudiobook is for audiobooks
+	CategoryAudioAudiobook Category = 3030
+	// CategoryAudioLossless is for lossless audio
+	CategoryAudioLossless Category = 3040
+	// CategoryAudioOther is for other audio
+	CategoryAudioOther Category = 3050
+	// CategoryAudioForeign is for foreign audio
+	CategoryAudioForeign Category = 3060
+
+	// PC categories
+	// CategoryPCAll is for all PC content
+	CategoryPCAll Category = 4000
+	// CategoryPC0day is for 0day software
+	CategoryPC0day Category = 4010
+	// CategoryPCISO is for software disc images
+	CategoryPCISO Category = 4020
+	// CategoryPCMac is for Mac software
+	CategoryPCMac Category = 4030
+	// CategoryPCMobileOther is for other mobile software
+	CategoryPCMobileOther Category = 4040
+	// CategoryPCGames is for PC games
+	CategoryPCGames Category = 4050
+	// CategoryPCMobileIOS is for iOS software
+	CategoryPCMobileIOS Category = 4060
+	// CategoryPCMobileAndroid is for Android software
+	CategoryPCMobileAndroid Category = 4070
+
+	// TV Categories
+	// CategoryTVAll is for all shows
+	CategoryTVAll Category = 5000
+	// CategoryTVWebDL is for shows downloaded from streaming services
+	CategoryTVWebDL Category = 5010
+	// CategoryTVForeign is for foreign shows
+	CategoryTVForeign Category = 5020
+	// CategoryTVSD is for standard-definition shows
+	CategoryTVSD Category = 5030
+	// CategoryTVHD is for high-definition shows
+	CategoryTVHD Category = 5040
+	// CategoryTVUHD is for ultra-high-definition shows
+	CategoryTVUHD Category = 5045
+	// CategoryTVOther is for other shows
+	CategoryTVOther Category = 5050
+	// CategoryTVSport is for sports shows
+	CategoryTVSport Category = 5060
+	// CategoryTVAnime is for anime
+	CategoryTVAnime Category = 5070
+	// CategoryTVDocumentary is for documentaries
+	CategoryTVDocumentary Category = 5080
+
+	// XXX categories
+	// CategoryXXXAll is for all adult content
+	CategoryXXXAll Category = 6000
+	// CategoryXXXDVD is for adult DVDs
+	CategoryXXXDVD Category = 6010
+	// CategoryXXXWMV is for adult WMV videos
+	CategoryXXXWMV Category = 6020
+	// CategoryXXXXviD is for adult XviD videos
+	CategoryXXXXviD Category = 6030
+	// CategoryXXXx264 is for adult x264 videos
+	CategoryXXXx264 Category = 6040
+	// CategoryXXXUHD is for adult ultra-high-definition videos
+	CategoryXXXUHD Category = 6045
+	// CategoryXXXPack is for adult packs
+	CategoryXXXPack Category = 6050
+	// CategoryXXXImageSet is for adult image sets
+	CategoryXXXImageSet Category = 6060
+	// CategoryXXXOther is for other adult content
+	CategoryXXXOther Category = 6070
+
+	// Book categories
+	// CategoryBooksAll is for all books
+	CategoryBooksAll Category = 7000
+	// CategoryBooksMagazines is for magazines
+	CategoryBooksMagazines Category = 7010
+	// CategoryBooksEbook is for ebooks
+	CategoryBooksEbook Category = 7020
+	// CategoryBooksComics is for comics
+	CategoryBooksComics Category = 7030
+	// CategoryBooksTechnical is for technical books
+	CategoryBooksTechnical Category = 7040
+	// CategoryBooksOther is for other books
+	CategoryBooksOther Category = 7050
+	// CategoryBooksForeign is for foreign books
+	CategoryBooksForeign Category = 7060
+
+	// Other categories
+	// CategoryOtherAll is for all other content
+	CategoryOtherAll Category = 8000
+	// CategoryOtherMisc is for miscellaneous content
+	CategoryOtherMisc Category = 8010
+	// CategoryOtherHashed is for content with obfuscated names
+	CategoryOtherHashed Category = 8020
 )
+
+// categoryNames maps each standard category to its name, in the
+// Parent/Subcategory form used by newznab and torznab caps documents
+var categoryNames = map[Category]string{
+	CategoryConsoleAll:        "Console",
+	CategoryConsoleNDS:        "Console/NDS",
+	CategoryConsolePSP:        "Console/PSP",
+	CategoryConsoleWii:        "Console/Wii",
+	CategoryConsoleXbox:       "Console/Xbox",
+	CategoryConsoleXbox360:    "Console/Xbox 360",
+	CategoryConsoleWiiware:    "Console/Wiiware",
+	CategoryConsoleXbox360DLC: "Console/Xbox 360 DLC",
+	CategoryConsolePS3:        "Console/PS3",
+	CategoryConsoleOther:      "Console/Other",
+	CategoryConsole3DS:        "Console/3DS",
+	CategoryConsolePSVita:     "Console/PS Vita",
+	CategoryConsoleWiiU:       "Console/WiiU",
+	CategoryConsoleXboxOne:    "Console/Xbox One",
+	CategoryConsolePS4:        "Console/PS4",
+
+	CategoryMovieAll:     "Movies",
+	CategoryMovieForeign: "Movies/Foreign",
+	CategoryMovieOther:   "Movies/Other",
+	CategoryMovieSD:      "Movies/SD",
+	CategoryMovieHD:      "Movies/HD",
+	CategoryMovieUHD:     "Movies/UHD",
+	CategoryMovieBluRay:  "Movies/BluRay",
+	CategoryMovie3D:      "Movies/3D",
+	CategoryMovieDVD:     "Movies/DVD",
+	CategoryMovieWebDL:   "Movies/WEB-DL",
+
+	CategoryAudioAll:       "Audio",
+	CategoryAudioMP3:       "Audio/MP3",
+	CategoryAudioVideo:     "Audio/Video",
+	CategoryAudioAudiobook: "Audio/Audiobook",
+	CategoryAudioLossless:  "Audio/Lossless",
+	CategoryAudioOther:     "Audio/Other",
+	CategoryAudioForeign:   "Audio/Foreign",
+
+	CategoryPCAll:           "PC",
+	CategoryPC0day:          "PC/0day",
+	CategoryPCISO:           "PC/ISO",
+	CategoryPCMac:           "PC/Mac",
+	CategoryPCMobileOther:   "PC/Mobile-Other",
+	CategoryPCGames:         "PC/Games",
+	CategoryPCMobileIOS:     "PC/Mobile-iOS",
+	CategoryPCMobileAndroid: "PC/Mobile-Android",
+
+	CategoryTVAll:         "TV",
+	CategoryTVWebDL:       "TV/WEB-DL",
+	CategoryTVForeign:     "TV/Foreign",
+	CategoryTVSD:          "TV/SD",
+	CategoryTVHD:          "TV/HD",
+	CategoryTVUHD:         "TV/UHD",
+	CategoryTVOther:       "TV/Other",
+	CategoryTVSport:       "TV/Sport",
+	CategoryTVAnime:       "TV/Anime",
+	CategoryTVDocumentary: "TV/Documentary",
+
+	CategoryXXXAll:      "XXX",
+	CategoryXXXDVD:      "XXX/DVD",
+	CategoryXXXWMV:      "XXX/WMV",
+	CategoryXXXXviD:     "XXX/XviD",
+	CategoryXXXx264:     "XXX/x264",
+	CategoryXXXUHD:      "XXX/UHD",
+	CategoryXXXPack:     "XXX/Pack",
+	CategoryXXXImageSet: "XXX/ImageSet",
+	CategoryXXXOther:    "XXX/Other",
+
+	CategoryBooksAll:       "Books",
+	CategoryBooksMagazines: "Books/Mags",
+	CategoryBooksEbook:     "Books/EBook",
+	CategoryBooksComics:    "Books/Comics",
+	CategoryBooksTechnical: "Books/Technical",
+	CategoryBooksOther:     "Books/Other",
+	CategoryBooksForeign:   "Books/Foreign",
+
+	CategoryOtherAll:    "Other",
+	CategoryOtherMisc:   "Other/Misc",
+	CategoryOtherHashed: "Other/Hashed",
+}
+
+// String returns the name of the category, e.g. "TV/HD".  Categories that are
+// not in the standard table are returned as their numeric ID.
+func (c Category) String() string {
+	if name, ok := categoryNames[c]; ok {
+		return name
+	}
+	return strconv.Itoa(int(c))
+}
+
+// IsStandard returns whether the category is in the standard newznab
+// category table
+func (c Category) IsStandard() bool {
+	_, ok := categoryNames[c]
+	return ok
+}
+
+// IsCustom returns whether the category is an indexer specific custom
+// category
+func (c Category) IsCustom() bool { return c >= CategoryCustomMin }
+
+// IsParent returns whether the category heads a group of categories, e.g.
+// CategoryTVAll
+func (c Category) IsParent() bool {
+	return c > 0 && !c.IsCustom() && c%1000 == 0
+}
+
+// Parent returns the category heading the group the category belongs to,
+// e.g. CategoryTVAll for CategoryTVHD.  Parent categories, and custom
+// categories whose place in the hierarchy is not known, are returned as is.
+func (c Category) Parent() Category {
+	if c <= 0 || c.IsCustom() {
+		return c
+	}
+	return c - c%1000
+}
+
+// IsChildOf returns whether the category is a subcategory of the given
+// parent category
+func (c Category) IsChildOf(parent Category) bool {
+	return c != parent && c.Parent() == parent
+}
+
+// ParseCategory parses a category from either its numeric ID, e.g. "5040",
+// or its standard name, e.g. "TV/HD".  Names are matched case-insensitively,
+// and "TV > HD" is accepted as an alternative to "TV/HD".
+func ParseCategory(s string) (Category, error) {
+	s = strings.TrimSpace(s)
+	if id, err := strconv.Atoi(s); err == nil {
+		if id <= 0 {
+			return 0, errors.Errorf("invalid category ID: %v", s)
+		}
+		return Category(id), nil
+	}
+	name := strings.Replace(s, " > ", "/", -1)
+	for c, n := range categoryNames {
+		if strings.EqualFold(n, name) {
+			return c, nil
+		}
+	}
+	return 0, errors.Errorf("unknown category: %v", s)
+}
@@ -0,0 +1,85 @@
+package newznab
+
+import "testing"
+
+func TestCategoryString(t *testing.T) {
+	cases := map[Category]string{
+		CategoryTVHD:       "TV/HD",
+		CategoryMovieAll:   "Movies",
+		CategoryConsolePS4: "Console/PS4",
+		CategoryOtherAll:   "Other",
+		Category(5999):     "5999",
+		Category(100004):   "100004",
+	}
+	for c, expected := range cases {
+		if c.String() != expected {
+			t.Errorf("Category(%d).String() = %q; expected %q", int(c), c.String(), expected)
+		}
+	}
+}
+
+func TestParseCategory(t *testing.T) {
+	cases := map[string]Category{
+		"5040":            CategoryTVHD,
+		" 2000 ":          CategoryMovieAll,
+		"100004":          Category(100004),
+		"TV/HD":           CategoryTVHD,
+		"books/ebook":     CategoryBooksEbook,
+		"Movies > BluRay": CategoryMovieBluRay,
+	}
+	for in, expected := range cases {
+		c, err := ParseCategory(in)
+		if err != nil {
+			t.Errorf("ParseCategory(%q) failed; %v", in, err)
+			continue
+		}
+		if c != expected {
+			t.Errorf("ParseCategory(%q) = %d; expected %d", in, c, expected)
+		}
+	}
+	for _, in := range []string{"", "0", "-5", "TV/Nonexistent"} {
+		if _, err := ParseCategory(in); err == nil {
+			t.Errorf("ParseCategory(%q) should have errored", in)
+		}
+	}
+}
+
+func TestEntryInvalidCategories(t *testing.T) {
+	raw := rawEntry{Attributes: []rawAttribute{
+		{Name: "category", Value: "0"},
+		{Name: "category", Value: "5040"},
+		{Name: "category", Value: "TV/Nonexistent"},
+	}}
+	entry := new(Entry)
+	if err := entry.fromRawEntry(raw, nil, DefaultAttributeRegistry); err != nil {
+		t.Fatalf("Invalid categories should not fail the entry; %v", err)
+	}
+	if categories := entry.General.Categorisation.Category; len(categories) != 1 || categories[0] != CategoryTVHD {
+		t.Errorf("Only the valid category should be parsed; got %v", categories)
+	}
+	if len(entry.Meta.Warnings) != 2 {
+		t.Errorf("Expected a warning for each invalid category; got %v", entry.Meta.Warnings)
+	}
+	if values := entry.Attributes.Values("category"); len(values) != 3 {
+		t.Errorf("Invalid categories should remain in Attributes; got %v", values)
+	}
+}
+
+func TestCategoryHierarchy(t *testing.T) {
+	if CategoryTVHD.Parent() != CategoryTVAll || CategoryTVAll.Parent() != CategoryTVAll {
+		t.Errorf("Wrong parent; got %v, %v", CategoryTVHD.Parent(), CategoryTVAll.Parent())
+	}
+	if !CategoryTVHD.IsChildOf(CategoryTVAll) || CategoryTVAll.IsChildOf(CategoryTVAll) || CategoryTVHD.IsChildOf(CategoryMovieAll) {
+		t.Errorf("Wrong IsChildOf results")
+	}
+	if !CategoryXXXAll.IsParent() || CategoryXXXUHD.IsParent() {
+		t.Errorf("Wrong IsParent results")
+	}
+	custom := Category(100004)
+	if !custom.IsCustom() || custom.IsStandard() || custom.Parent() != custom || custom.IsChildOf(CategoryTVAll) {
+		t.Errorf("Custom category should have no known place in the hierarchy")
+	}
+	if !CategoryTVAnime.IsStandard() || CategoryTVAnime.IsCustom() {
+		t.Errorf("Standard category misclassified")
+	}
+}
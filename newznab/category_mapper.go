@@ -0,0 +1,159 @@
+package newznab
+
+import "sort"
+
+// CategoryMapper translates between the category IDs used by a particular
+// indexer and the standard newznab categories.  Indexers, torznab ones in
+// particular, often advertise custom categories (IDs of CategoryCustomMin and
+// above) whose only relation to the standard categories is their position and
+// name in the indexer's caps document.
+type CategoryMapper struct {
+	// standard category each indexer category maps to
+	toStandard map[Category]Category
+	// indexer categories mapping to each standard category, in the order
+	// they are advertised
+	toIndexer map[Category][]Category
+}
+
+// NewCategoryMapper builds a CategoryMapper from the categories advertised in
+// the given capabilities.  Custom subcategories are mapped to the standard
+// category matching their name where there is one, e.g. "HD" under "TV" or
+// "TV/HD", and otherwise to the standard category they are listed under.
+// Custom top level categories are mapped by name only.
+func NewCategoryMapper(caps Capabilities) *CategoryMapper {
+	m := &CategoryMapper{
+		toStandard: make(map[Category]Category),
+		toIndexer:  make(map[Category][]Category),
+	}
+	for _, cat := range caps.Categories {
+		parent := standardCategoryFor(cat.ID, cat.Name, "")
+		if parent != 0 {
+			m.add(cat.ID, parent)
+		}
+		for _, sub := range cat.Subcategories {
+			standard := standardCategoryFor(sub.ID, sub.Name, cat.Name)
+			if standard == 0 {
+				standard = parent
+			}
+			if standard != 0 {
+				m.add(sub.ID, standard)
+			}
+		}
+	}
+	return m
+}
+
+// standardCategoryFor returns the standard category an advertised category
+// corresponds to, based on its ID or name, or 0 if there is none
+func standardCategoryFor(id Category, name, parentName string) Category {
+	if id.IsStandard() {
+		return id
+	}
+	if c, err := ParseCategory(name); err == nil && c.IsStandard() {
+		return c
+	}
+	if parentName != "" {
+		if c, err := ParseCategory(parentName + "/" + name); err == nil && c.IsStandard() {
+			return c
+		}
+	}
+	return 0
+}
+
+// add records a mapping between an indexer category and a standard category
+func (m *CategoryMapper) add(indexer, standard Category) {
+	if _, ok := m.toStandard[indexer]; ok {
+		return
+	}
+	m.toStandard[indexer] = standard
+	m.toIndexer[standard] = append(m.toIndexer[standard], indexer)
+}
+
+// ToStandard returns the standard category the given indexer category maps
+// to.  Categories with no known mapping are returned as is.
+func (m *CategoryMapper) ToStandard(c Category) Category {
+	if standard, ok := m.toStandard[c]; ok {
+		return standard
+	}
+	return c
+}
+
+// ToIndexer returns the indexer categories to request in order to search
+// the given standard categories.  A parent category also expands to the
+// custom categories mapped to any of its subcategories, as indexers do not
+// include custom categories when searching a standard parent.  Categories
+// with no known mapping are passed through as is.
+func (m *CategoryMapper) ToIndexer(cats ...Category) []Category {
+	var out []Category
+	seen := make(map[Category]bool)
+	appendUnseen := func(c Category) {
+		if !seen[c] {
+			seen[c] = true
+			out = append(out, c)
+		}
+	}
+	for _, c := range cats {
+		mapped, ok := m.toIndexer[c]
+		if !ok && !c.IsParent() {
+			appendUnseen(c)
+			continue
+		}
+		for _, indexer := range mapped {
+			appendUnseen(indexer)
+		}
+		if c.IsParent() {
+			var children []int
+			for standard := range m.toIndexer {
+				if standard.IsChildOf(c) {
+					children = append(children, int(standard))
+				}
+			}
+			sort.Ints(children)
+			for _, child := range children {
+				for _, indexer := range m.toIndexer[Category(child)] {
+					if indexer.IsCustom() {
+						appendUnseen(indexer)
+					}
+				}
+			}
+			if !ok {
+				appendUnseen(c)
+			}
+		}
+	}
+	return out
+}
+
+// Normalise returns the standard categories corresponding to the given
+// indexer categories, with duplicates removed
+func (m *CategoryMapper) Normalise(cats []Category) []Category {
+	var out []Category
+	seen := make(map[Category]bool)
+	for _, c := range cats {
+		standard := m.ToStandard(c)
+		if !seen[standard] {
+			seen[standard] = true
+			out = append(out, standard)
+		}
+	}
+	return out
+}
+
+// NormaliseEntries rewrites the categories of each Entry to standard
+// categories, keeping the indexer's own categories in IndexerCategory
+func (m *CategoryMapper) NormaliseEntries(entries Entries) {
+	for i := range entries {
+		categorisation := &entries[i].General.Categorisation
+		categorisation.IndexerCategory = categorisation.Category
+		categorisation.Category = m.Normalise(categorisation.Category)
+	}
+}
+
+// categoryMapper returns a CategoryMapper for the given capabilities if the
+// Client is configured to map categories, or nil otherwise
+func (c *Client) categoryMapper(caps Capabilities) *CategoryMapper {
+	if !c.MapCategories {
+		return nil
+	}
+	return NewCategoryMapper(caps)
+}
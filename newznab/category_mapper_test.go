@@ -0,0 +1,108 @@
+package newznab
+
+import (
+	"context"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func loadTorznabCapabilities(t *testing.T) Capabilities {
+	data, err := ioutil.ReadFile("../tests/fixtures/torznab/caps.xml")
+	if err != nil {
+		t.Fatalf("Failed to read fixture; %v", err)
+	}
+	raw := new(rawCapabilities)
+	if err = xml.Unmarshal(data, raw); err != nil {
+		t.Fatalf("Failed to unmarshal fixture; %v", err)
+	}
+	return capabilitiesFromRaw(*raw)
+}
+
+func TestCategoryMapperToStandard(t *testing.T) {
+	m := NewCategoryMapper(loadTorznabCapabilities(t))
+	cases := map[Category]Category{
+		CategoryMovieHD:  CategoryMovieHD,
+		Category(100001): CategoryMovieUHD,
+		Category(100002): CategoryMovieAll,
+		Category(100003): CategoryTVHD,
+		Category(100004): CategoryTVAll,
+		Category(100005): CategoryBooksEbook,
+		Category(100006): Category(100006),
+		Category(100099): Category(100099),
+	}
+	for indexer, expected := range cases {
+		if got := m.ToStandard(indexer); got != expected {
+			t.Errorf("ToStandard(%d) = %d; expected %d", indexer, got, expected)
+		}
+	}
+	if got := m.Normalise([]Category{5000, 100003, 100004}); !reflect.DeepEqual(got, []Category{CategoryTVAll, CategoryTVHD}) {
+		t.Errorf("Wrong normalised categories; got %v", got)
+	}
+}
+
+func TestCategoryMapperToIndexer(t *testing.T) {
+	m := NewCategoryMapper(loadTorznabCapabilities(t))
+	cases := []struct {
+		in       []Category
+		expected []Category
+	}{
+		{[]Category{CategoryTVHD}, []Category{100003}},
+		{[]Category{CategoryTVAll}, []Category{5000, 100004, 100003}},
+		{[]Category{CategoryMovieUHD, CategoryMovieHD}, []Category{100001, 2040}},
+		{[]Category{CategoryBooksAll}, []Category{100005, CategoryBooksAll}},
+		{[]Category{CategoryAudioMP3}, []Category{CategoryAudioMP3}},
+	}
+	for _, c := range cases {
+		if got := m.ToIndexer(c.in...); !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("ToIndexer(%v) = %v; expected %v", c.in, got, c.expected)
+		}
+	}
+}
+
+func TestDoMapCategories(t *testing.T) {
+	var requestedCategories string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("t") == "caps" {
+			http.ServeFile(w, r, "../tests/fixtures/torznab/caps.xml")
+			return
+		}
+		requestedCategories = r.URL.Query().Get("cat")
+		http.ServeFile(w, r, "../tests/fixtures/torznab/search.xml")
+	}))
+	defer ts.Close()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse mock server URL")
+	}
+	client := &Client{HTTPClient: &http.Client{}, BaseURL: u, APIKey: "gibberish", MapCategories: true}
+
+	result, err := client.Do(context.Background(), SearchRequest{
+		Function:   FunctionSearch,
+		Query:      "The Expanse",
+		Categories: []Category{CategoryTVHD},
+	})
+	if err != nil {
+		t.Fatalf("Failed to search mock indexer; %v", err)
+	}
+	if requestedCategories != "100003" {
+		t.Errorf("Request should use the indexer's categories; got %q", requestedCategories)
+	}
+	if len(result.Entries) != 2 {
+		t.Fatalf("Wrong number of results; got %d expected %d", len(result.Entries), 2)
+	}
+	categorisation := result.Entries[0].General.Categorisation
+	if !reflect.DeepEqual(categorisation.Category, []Category{CategoryTVAll, CategoryTVHD}) {
+		t.Errorf("Wrong normalised categories; got %v", categorisation.Category)
+	}
+	if !reflect.DeepEqual(categorisation.IndexerCategory, []Category{5000, 100003}) {
+		t.Errorf("Wrong indexer categories; got %v", categorisation.IndexerCategory)
+	}
+	if got := result.Entries[1].General.Categorisation.Category; !reflect.DeepEqual(got, []Category{CategoryTVAll}) {
+		t.Errorf("Wrong normalised categories; got %v", got)
+	}
+}
@@ -3,6 +3,7 @@ package newznab
 import (
 	"net/http"
 	"net/url"
+	"sync"
 )
 
 // ModePath is a string type that describes the path to append to a base URL
@@ -25,8 +26,38 @@ type Client struct {
 	BaseURL *url.URL
 	// http client to use for interactions with the API
 	HTTPClient *http.Client
+	// policy deciding whether failed requests are retried; if nil, requests
+	// are not retried
+	RetryPolicy RetryPolicy
+	// optional limiter applied to every request made to the indexer
+	RateLimiter RateLimiter
+	// optional tracker of API hit and download quotas; requests that would
+	// exceed it fail with a *QuotaExceededError without being made
+	Quota *Quota
+	// enrichments to perform on every Entry returned by a search, unless
+	// overridden with SearchOptions; by default no enrichment is performed
+	Enrichments Enrichment
+	// maximum number of Entries enriched concurrently during a search; if
+	// zero, DefaultEnrichmentConcurrency is used
+	EnrichmentConcurrency int
+	// whether searches request every extended attribute by default, unless
+	// overridden with SearchOptions
+	Extended bool
+	// handlers used to parse the attributes of items in feeds; if nil,
+	// DefaultAttributeRegistry is used
+	AttributeRegistry *AttributeRegistry
+	// parser used for dates in feeds; if nil, the DefaultDateLayouts are
+	// used
+	DateParser *DateParser
+	// whether searches made with Do and SearchAll translate categories using
+	// a CategoryMapper built from the indexer's capabilities, so that
+	// requests use the indexer's category IDs and results report standard
+	// categories
+	MapCategories bool
 	// stores capability information retrieved from the API;
 	// this describes things like details on what is indexed, supported functions
-	// , etc
-	capabilities Capabilities
+	// , etc.  It is nil until the capabilities have been fetched
+	capabilities *Capabilities
+	// guards capabilities
+	capabilitiesMu sync.Mutex
 }
@@ -1,32 +1,132 @@
 package newznab
 
 import (
+	"context"
+	"fmt"
 	"io/ioutil"
+	"net/http"
 	"net/url"
-
-	"github.com/smquartz/errors"
 )
 
 // buildURL produces a *url.URL that is made up of the base path specified in
 // the Client instance, and the path and query parameters specified as arguments
-func (c Client) buildURL(path ModePath, values url.Values) *url.URL {
+func (c *Client) buildURL(path ModePath, values url.Values) *url.URL {
 	u := *c.BaseURL
 	u.Path = string(path)
 	u.RawQuery = values.Encode()
 	return &u
 }
 
+// callAPI performs the given API function with the given parameters,
+// authenticated with the Client's API key, and returns the response body
+func (c *Client) callAPI(ctx context.Context, function string, values url.Values) ([]byte, error) {
+	if values == nil {
+		values = url.Values{}
+	}
+	values.Set("t", function)
+	if c.APIKey != "" {
+		values.Set("apikey", c.APIKey)
+	}
+	return c.getURLResponseBody(ctx, RequestAPI, c.buildURL(ModePathAPI, values))
+}
+
 // getURLResponseBody is a helper function that performs a GET request on a specified URL,
-// and returns the response body as a byte slice
-func (c *Client) getURLResponseBody(u *url.URL) (data []byte, err error) {
-	rsp, err := c.HTTPClient.Get(u.String())
+// and returns the response body as a byte slice.  The request is bound to the
+// given context.  A non-2xx response is returned as an *HTTPError, and a
+// newznab error response as an *APIError.  Failed requests are retried
+// according to the Client's RetryPolicy, if any, unless they call one of the
+// nonIdempotentFunctions.  Each attempt that receives a response counts
+// against the Client's Quota for the given kind of request.
+func (c *Client) getURLResponseBody(ctx context.Context, kind RequestKind, u *url.URL) (data []byte, err error) {
+	retryable := c.RetryPolicy != nil && !nonIdempotentFunctions[u.Query().Get("t")]
+	for attempt := 1; ; attempt++ {
+		data, err = c.getURLResponseBodyOnce(ctx, kind, u)
+		if err == nil || !retryable {
+			break
+		}
+		delay, retry := c.RetryPolicy.Retry(attempt, err)
+		if !retry {
+			break
+		}
+		if err = sleepContext(ctx, delay); err != nil {
+			// the context was cancelled while waiting to retry
+			return nil, err
+		}
+	}
+
+	switch e := err.(type) {
+	case nil:
+		return data, nil
+	case *APIError:
+		// the indexer knows better than our count; stop making requests
+		// of this kind until the quota resets
+		if c.Quota != nil {
+			switch e.Code {
+			case ErrorCodeRequestLimitReached:
+				c.Quota.exhaust(RequestAPI)
+			case ErrorCodeDownloadLimitReached:
+				c.Quota.exhaust(RequestDownload)
+			}
+		}
+		return nil, err
+	case *HTTPError, *QuotaExceededError:
+		return nil, err
+	default:
+		return nil, fmt.Errorf("error performing GET request on %v: %w", redactURL(u), err)
+	}
+}
+
+// getURLResponseBodyOnce performs a single attempt of getURLResponseBody.
+// Errors are returned unwrapped, so that they may be classified by a
+// RetryPolicy.
+func (c *Client) getURLResponseBodyOnce(ctx context.Context, kind RequestKind, u *url.URL) (data []byte, err error) {
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.RateLimiter != nil {
+		if err = c.RateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if c.Quota != nil {
+		if err = c.Quota.acquire(kind); err != nil {
+			return nil, err
+		}
+	}
+
+	rsp, err := c.HTTPClient.Do(req.WithContext(ctx))
 	if err != nil {
-		return nil, errors.Wrapf(err, "error performing GET request on %v", 1, u.String())
+		// without a response, the request is not counted against the quota
+		if c.Quota != nil {
+			c.Quota.release(kind)
+		}
+		return nil, err
 	}
+	defer rsp.Body.Close()
 
 	data, err = ioutil.ReadAll(rsp.Body)
 	if err != nil {
-		return nil, errors.Wrapf(err, "error reading response body", 1)
+		return nil, err
+	}
+
+	if c.Quota != nil {
+		c.Quota.syncHeader(kind, rsp.Header)
+	}
+
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		return nil, &HTTPError{
+			StatusCode: rsp.StatusCode,
+			Status:     rsp.Status,
+			URL:        redactURL(u),
+			Header:     rsp.Header,
+			Body:       data,
+		}
+	}
+
+	if err = apiErrorFromResponseBody(data); err != nil {
+		return nil, err
 	}
 
 	return data, nil
@@ -1,6 +1,7 @@
 package newznab
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"strings"
@@ -25,7 +26,7 @@ func TestGetURLResponseBody(t *testing.T) {
 		t.Fatalf("Could not parse test URL")
 	}
 	c := &Client{HTTPClient: &http.Client{}}
-	data, err := c.getURLResponseBody(testURL)
+	data, err := c.getURLResponseBody(context.Background(), RequestAPI, testURL)
 	if err != nil {
 		t.Errorf("getURLResponseBody failed; %v", err.Error())
 	}
@@ -38,7 +39,7 @@ func TestGetURLResponseBody(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Could not parse test URL")
 	}
-	_, err = c.getURLResponseBody(testURL)
+	_, err = c.getURLResponseBody(context.Background(), RequestAPI, testURL)
 	if err == nil {
 		t.Errorf("getURLResponseBody should have errored")
 	}
@@ -1,12 +1,11 @@
 package newznab
 
 import (
+	"context"
 	"encoding/xml"
 	"net/url"
-	"strings"
 	"time"
 
-	log "github.com/Sirupsen/logrus"
 	"github.com/smquartz/errors"
 )
 
@@ -40,18 +39,42 @@ type rawComment struct {
 	PublishedDate string `xml:"pubDate"`
 }
 
-// populateComments fetches and updates the Comments for the given newznab entry
+// FunctionCommentAdd is the API function name used to comment on an entry
+const FunctionCommentAdd = "commentadd"
+
+// AddComment posts a comment with the given text on the entry with the given
+// ID
+func (c *Client) AddComment(id EntryID, text string) error {
+	return c.AddCommentContext(context.Background(), id, text)
+}
+
+// AddCommentContext is like AddComment, but performs its request with the given
+// context
+func (c *Client) AddCommentContext(ctx context.Context, id EntryID, text string) error {
+	_, err := c.callAPI(ctx, FunctionCommentAdd, url.Values{
+		"id":   []string{id.APIValue()},
+		"text": []string{text},
+	})
+	return err
+}
+
+// PopulateComments fetches and updates the Comments for the given newznab entry
 func (entry *Entry) PopulateComments(c *Client) error {
-	idStr := strings.Replace(entry.Meta.ID.String(), "-", "", -1)
-	log.Println("ID IS", idStr)
+	return entry.PopulateCommentsContext(context.Background(), c)
+}
+
+// PopulateCommentsContext is like PopulateComments, but performs its request
+// with the given context
+func (entry *Entry) PopulateCommentsContext(ctx context.Context, c *Client) error {
+	idStr := entry.Meta.ID.APIValue()
 
-	data, err := c.getURLResponseBody(c.buildURL(ModePathAPI, url.Values{
+	data, err := c.getURLResponseBody(ctx, RequestAPI, c.buildURL(ModePathAPI, url.Values{
 		"t":      []string{"comments"},
 		"id":     []string{idStr},
 		"apikey": []string{c.APIKey},
 	}))
 	if err != nil {
-		return errors.Wrap(err, 1)
+		return err
 	}
 
 	rsp := new(rawComments)
@@ -65,13 +88,8 @@ func (entry *Entry) PopulateComments(c *Client) error {
 			Title:   rComment.Title,
 			Content: rComment.Description,
 		}
-		if parsedPubDate, err := time.Parse(time.RFC1123Z, rComment.PublishedDate); err != nil {
-			log.WithFields(log.Fields{
-				"pub_date": rComment.PublishedDate,
-				"err":      err,
-			}).Error("newznab:Client:PopulateComments: failed to parse date")
-		} else {
-			comment.Published = parsedPubDate
+		if published, ok := entry.parseDate(c.DateParser, "comment pubDate", rComment.PublishedDate); ok {
+			comment.Published = published
 		}
 		entry.Meta.Comments.Comments = append(entry.Meta.Comments.Comments, comment)
 	}
@@ -1,9 +1,12 @@
 package newznab
 
 import (
+	"context"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 )
 
 func TestPopulateComments(t *testing.T) {
@@ -26,3 +29,74 @@ func TestPopulateComments(t *testing.T) {
 		t.Errorf("Failed to populate comments for %v", results[1].Meta.ID.String())
 	}
 }
+
+func TestSearchEnrichComments(t *testing.T) {
+	ts := newMockServer()
+	defer ts.Close()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Errorf("Failed to parse mock server URL")
+	}
+	client := &Client{HTTPClient: &http.Client{}, BaseURL: u, APIKey: "gibberish"}
+	values := url.Values{
+		"rid":     []string{"2870"},
+		"cat":     stringifyCategories([]Category{CategoryTVSD}),
+		"season":  []string{"10"},
+		"episode": []string{"1"},
+		"t":       []string{"tvsearch"},
+	}
+
+	result, err := client.SearchWithOptions(context.Background(), values, SearchOptions{})
+	if err != nil {
+		t.Fatalf("Failed to search mock indexer; %v", err)
+	}
+	if len(result.Entries[1].Meta.Comments.Comments) != 0 {
+		t.Errorf("Comments should not be populated without EnrichComments")
+	}
+
+	result, err = client.SearchWithOptions(context.Background(), values, SearchOptions{Enrichments: EnrichComments})
+	if err != nil {
+		t.Fatalf("Failed to search mock indexer; %v", err)
+	}
+	if len(result.Entries[1].Meta.Comments.Comments) == 0 {
+		t.Errorf("Comments should be populated with EnrichComments")
+	}
+}
+
+func TestAddComment(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+
+	if err := client.AddComment(EntryID("85ae3c25b68a6f1870bc7f732b939045"), "Great release"); err != nil {
+		t.Errorf("AddComment failed; %v", err)
+	}
+}
+
+func TestPopulateCommentsDates(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<rss version="2.0"><channel>` +
+			`<item><title>a</title><description>first</description><pubDate>2010-06-06T17:29:23+01:00</pubDate></item>` +
+			`<item><title>b</title><description>second</description><pubDate>yesterday</pubDate></item>` +
+			`</channel></rss>`))
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	client := &Client{HTTPClient: &http.Client{}, BaseURL: u}
+
+	entry := Entry{}
+	entry.Meta.ID = EntryID("85ae3c25b68a6f1870bc7f732b939045")
+	if err := entry.PopulateCommentsContext(context.Background(), client); err != nil {
+		t.Fatalf("PopulateComments failed; %v", err)
+	}
+	comments := entry.Meta.Comments.Comments
+	if len(comments) != 2 {
+		t.Fatalf("Wrong number of comments; got %d expected %d", len(comments), 2)
+	}
+	expected := time.Date(2010, time.June, 6, 16, 29, 23, 0, time.UTC)
+	if !comments[0].Published.Equal(expected) {
+		t.Errorf("Wrong comment date; got %v expected %v", comments[0].Published, expected)
+	}
+	if !comments[1].Published.IsZero() || len(entry.Meta.Warnings) != 1 {
+		t.Errorf("Unparseable comment date should produce a warning; got %v, %v", comments[1].Published, entry.Meta.Warnings)
+	}
+}
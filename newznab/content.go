@@ -8,16 +8,21 @@ import (
 // TV is a Content implementation that describes an episode of a TV
 // series
 type TV struct {
+	// IDs of the series in external metadata databases
+	ExternalIDs
 	// air date for the episode according to the entry
 	AirDate time.Time
-	// ID for the entry for the episode in TheTVDB
-	TVDBID int64
-	// ID for the entry for the episode in TVRage
-	TVRageID int64
-	// absolute number of the season
+	// absolute number of the season; the year for daily episodes
 	Season uint
 	// number of the episode; entry dependent whether it is absolute or relative
 	Episode uint
+	// the episodes described by the entry, including ranges, season packs,
+	// air dates and absolute numbering; zero if they could not be parsed
+	EpisodeSpec EpisodeSpec
+	// season as given by the indexer, e.g. "S01"
+	RawSeason string
+	// episode as given by the indexer, e.g. "E1/12" or "special"
+	RawEpisode string
 	// canonical title of the episode
 	CanonicalTitle string
 	// rating of the episode as recorded in the newznab entry
@@ -38,10 +43,10 @@ func (t *TV) SetAired(date time.Time) { t.AirDate = date }
 
 // Movie is a Content implementation that describes a movie
 type Movie struct {
+	// IDs of the movie in external metadata databases
+	ExternalIDs
 	// the air date of the movie according to the newznab entry
 	AirDate time.Time
-	// ID of the entry for the movie in IMDB
-	IMDBID int64
 	// title of the movie as recorded by the IMDB entry
 	IMDBTitle string
 	// year of movie release as recorded by IMDB
@@ -64,6 +69,71 @@ func (m Movie) Aired() time.Time { return m.AirDate }
 // SetAired sets the air date of the movie to the value provided
 func (m *Movie) SetAired(date time.Time) { m.AirDate = date }
 
+// Music is a Content implementation that describes an album or track
+type Music struct {
+	// release date of the music according to the newznab entry
+	ReleaseDate time.Time
+	// artist who performed the music
+	Artist string
+	// album the music belongs to
+	Album string
+	// record label that released the music
+	Label string
+	// publisher of the music
+	Publisher string
+	// track title, if the entry describes a single track
+	Track string
+	// year the music was released
+	Year int
+	// genre of the music
+	Genre string
+}
+
+// IsContent is a dummy function that implements the Content interface
+func (Music) IsContent() {}
+
+// Title returns the album title, or the track title if there is no album
+func (m Music) Title() string {
+	if m.Album != "" {
+		return m.Album
+	}
+	return m.Track
+}
+
+// Aired returns the release date of the music
+func (m Music) Aired() time.Time { return m.ReleaseDate }
+
+// SetAired sets the release date of the music to the value provided
+func (m *Music) SetAired(date time.Time) { m.ReleaseDate = date }
+
+// Book is a Content implementation that describes a book, ebook or audiobook
+type Book struct {
+	// publication date of the book
+	PublishDate time.Time
+	// title of the book
+	BookTitle string
+	// author of the book
+	Author string
+	// publisher of the book
+	Publisher string
+	// number of pages in the book
+	Pages int
+	// ISBN of the book
+	ISBN string
+}
+
+// IsContent is a dummy function that implements the Content interface
+func (Book) IsContent() {}
+
+// Title returns the title of the book
+func (b Book) Title() string { return b.BookTitle }
+
+// Aired returns the publication date of the book
+func (b Book) Aired() time.Time { return b.PublishDate }
+
+// SetAired sets the publication date of the book to the value provided
+func (b *Book) SetAired(date time.Time) { b.PublishDate = date }
+
 // Content describes the actual content that an entry corresponds to;
 // that is, it describes the movie or episode
 type Content interface {
@@ -0,0 +1,180 @@
+package newznab
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/smquartz/errors"
+)
+
+// DefaultDateLayouts are the layouts tried, in order, by a DateParser with no
+// layouts of its own.  Days may be given with one or two digits, and the
+// seconds may be omitted.
+var DefaultDateLayouts = []string{
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"Mon, 2 Jan 2006 15:04 -0700",
+	"Mon, 2 Jan 2006 15:04 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04 -0700",
+	"2 Jan 2006 15:04 MST",
+	"Mon, 2 Jan 06 15:04:05 -0700",
+	"Mon, 2 Jan 06 15:04:05 MST",
+	"Mon, 2 Jan 06 15:04 -0700",
+	"Mon, 2 Jan 06 15:04 MST",
+	"2 Jan 06 15:04 -0700",
+	"2 Jan 06 15:04 MST",
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// zoneOffsets holds the UTC offsets of zone abbreviations commonly found in
+// feeds, which time.Parse only understands when they belong to the local
+// time zone
+var zoneOffsets = map[string]int{
+	"UT":  0,
+	"UTC": 0,
+	"GMT": 0,
+	"Z":   0,
+	"EST": -5 * 60 * 60,
+	"EDT": -4 * 60 * 60,
+	"CST": -6 * 60 * 60,
+	"CDT": -5 * 60 * 60,
+	"MST": -7 * 60 * 60,
+	"MDT": -6 * 60 * 60,
+	"PST": -8 * 60 * 60,
+	"PDT": -7 * 60 * 60,
+	"BST": 1 * 60 * 60,
+	"CET": 1 * 60 * 60,
+}
+
+// DateParser parses the dates found in newznab feeds, which in practice use
+// a wide variety of formats.  The zero value is ready to use.
+type DateParser struct {
+	// layouts to try, in order; if empty, DefaultDateLayouts is used
+	Layouts []string
+	// location assumed for dates without a time zone; if nil, UTC is used
+	Location *time.Location
+}
+
+// defaultDateParser is used where no DateParser has been configured
+var defaultDateParser = &DateParser{}
+
+// numericDateLayouts are the layouts of dates made up only of digits, by
+// length; longer numbers are taken to be unix timestamps
+var numericDateLayouts = map[int]string{
+	4: "2006",
+	8: "20060102",
+}
+
+// Parse parses a date using the first layout that matches.  Unix timestamps,
+// in seconds or milliseconds, are also accepted, as are years and dates of the
+// form YYYYMMDD.
+func (p *DateParser) Parse(date string) (time.Time, error) {
+	if p == nil {
+		p = defaultDateParser
+	}
+	date = strings.Join(strings.Fields(date), " ")
+	if date == "" {
+		return time.Time{}, errors.Errorf("failed to parse empty date")
+	}
+	location := p.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	if strings.Trim(date, "0123456789") == "" {
+		return parseNumericDate(date, location)
+	}
+
+	// time.Parse requires zone abbreviations to be at least three letters
+	if strings.HasSuffix(date, " UT") || strings.HasSuffix(date, " Z") {
+		date = date[:strings.LastIndex(date, " ")] + " UTC"
+	}
+
+	layouts := p.Layouts
+	if len(layouts) == 0 {
+		layouts = DefaultDateLayouts
+	}
+	for _, layout := range layouts {
+		parsed, err := time.ParseInLocation(layout, date, location)
+		if err == nil {
+			return fixZoneOffset(parsed), nil
+		}
+	}
+	return time.Time{}, errors.Errorf("failed to parse date %q as any of %d layouts", date, len(layouts))
+}
+
+// parseNumericDate parses a date made up only of digits; a year, a date of
+// the form YYYYMMDD, or a unix timestamp in seconds or milliseconds.  Other
+// numbers are too ambiguous to be parsed.
+func parseNumericDate(date string, location *time.Location) (time.Time, error) {
+	if layout, ok := numericDateLayouts[len(date)]; ok {
+		parsed, err := time.ParseInLocation(layout, date, location)
+		if err != nil {
+			return time.Time{}, errors.Wrapf(err, "failed to parse date %q", 1, date)
+		}
+		return parsed, nil
+	}
+	if len(date) < 9 {
+		return time.Time{}, errors.Errorf("failed to parse number %q as a date", date)
+	}
+	unix, err := strconv.ParseInt(date, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "failed to parse unix timestamp %q", 1, date)
+	}
+	if len(date) >= 13 {
+		return time.Unix(unix/1000, unix%1000*int64(time.Millisecond)).UTC(), nil
+	}
+	return time.Unix(unix, 0).UTC(), nil
+}
+
+// fixZoneOffset corrects the offset of a time parsed with a zone abbreviation
+// that time.Parse did not recognise, and so assumed to be UTC
+func fixZoneOffset(t time.Time) time.Time {
+	name, offset := t.Zone()
+	known, ok := zoneOffsets[name]
+	if !ok || offset == known {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(),
+		time.FixedZone(name, known))
+}
+
+// ParseWarning describes a value in a feed that could not be parsed, but
+// which did not prevent the rest of the Entry from being parsed
+type ParseWarning struct {
+	// name of the element or attribute the value came from, e.g. pubDate
+	Field string
+	// value that could not be parsed
+	Value string
+	// reason the value could not be parsed
+	Err error
+}
+
+// Error implements the error interface
+func (w ParseWarning) Error() string {
+	return "failed to parse " + w.Field + " " + strconv.Quote(w.Value) + ": " + w.Err.Error()
+}
+
+// addWarning records a value in the Entry's source that could not be parsed
+func (e *Entry) addWarning(field, value string, err error) {
+	e.Meta.Warnings = append(e.Meta.Warnings, ParseWarning{Field: field, Value: value, Err: err})
+}
+
+// parseDate parses a date found in the Entry's source with the given parser,
+// recording a ParseWarning rather than failing if it cannot be parsed.  The
+// boolean return value is false if the date could not be parsed.
+func (e *Entry) parseDate(dates *DateParser, field, value string) (time.Time, bool) {
+	parsed, err := dates.Parse(value)
+	if err != nil {
+		e.addWarning(field, value, err)
+		return time.Time{}, false
+	}
+	return parsed, true
+}
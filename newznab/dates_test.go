@@ -0,0 +1,98 @@
+package newznab
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDateParserParse(t *testing.T) {
+	cases := map[string]time.Time{
+		"Sun, 05 Mar 2017 04:07:00 -0500":     time.Date(2017, time.March, 5, 9, 7, 0, 0, time.UTC),
+		"Sun, 5 Mar 2017 04:07:00 -0500":      time.Date(2017, time.March, 5, 9, 7, 0, 0, time.UTC),
+		"Sun, 05 Mar 2017 04:07:00 GMT":       time.Date(2017, time.March, 5, 4, 7, 0, 0, time.UTC),
+		"Sun, 05 Mar 2017 04:07:00 PST":       time.Date(2017, time.March, 5, 12, 7, 0, 0, time.UTC),
+		"Sun, 05 Mar 2017 04:07 EST":          time.Date(2017, time.March, 5, 9, 7, 0, 0, time.UTC),
+		"05 Mar 17 04:07 +0000":               time.Date(2017, time.March, 5, 4, 7, 0, 0, time.UTC),
+		"5 Mar 2017 04:07:00 UT":              time.Date(2017, time.March, 5, 4, 7, 0, 0, time.UTC),
+		"2017-03-05T04:07:00+01:00":           time.Date(2017, time.March, 5, 3, 7, 0, 0, time.UTC),
+		"2017-03-05 04:07:00":                 time.Date(2017, time.March, 5, 4, 7, 0, 0, time.UTC),
+		"2017-03-05":                          time.Date(2017, time.March, 5, 0, 0, 0, 0, time.UTC),
+		"1488686820":                          time.Date(2017, time.March, 5, 4, 7, 0, 0, time.UTC),
+		"1488686820000":                       time.Date(2017, time.March, 5, 4, 7, 0, 0, time.UTC),
+		"2005":                                time.Date(2005, time.January, 1, 0, 0, 0, 0, time.UTC),
+		"20170305":                            time.Date(2017, time.March, 5, 0, 0, 0, 0, time.UTC),
+		"951782400":                           time.Date(2000, time.February, 29, 0, 0, 0, 0, time.UTC),
+		"  Sun,  05 Mar 2017 04:07:00 +0000 ": time.Date(2017, time.March, 5, 4, 7, 0, 0, time.UTC),
+	}
+	var p *DateParser
+	for in, expected := range cases {
+		parsed, err := p.Parse(in)
+		if err != nil {
+			t.Errorf("Parse(%q) failed; %v", in, err)
+			continue
+		}
+		if !parsed.Equal(expected) {
+			t.Errorf("Parse(%q) = %v; expected %v", in, parsed, expected)
+		}
+	}
+	for _, in := range []string{"", "TBA", "32 Mar 2017", "0", "170305", "20171305", "99999999999999999999"} {
+		if _, err := p.Parse(in); err == nil {
+			t.Errorf("Parse(%q) should have errored", in)
+		}
+	}
+}
+
+func TestDateParserConfigured(t *testing.T) {
+	loc := time.FixedZone("AEST", 10*60*60)
+	p := &DateParser{Layouts: []string{"02/01/2006 15:04"}, Location: loc}
+	parsed, err := p.Parse("05/03/2017 14:07")
+	if err != nil {
+		t.Fatalf("Parse failed; %v", err)
+	}
+	if expected := time.Date(2017, time.March, 5, 14, 7, 0, 0, loc); !parsed.Equal(expected) {
+		t.Errorf("Parse = %v; expected %v", parsed, expected)
+	}
+	if _, err = p.Parse("2017-03-05"); err == nil {
+		t.Errorf("Configured layouts should replace the defaults")
+	}
+}
+
+func TestSearchRecordsDateWarnings(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+
+	result, err := client.Do(context.Background(), SearchRequest{
+		Function:   FunctionSearch,
+		Query:      "Dates",
+		Categories: []Category{CategoryTVAll},
+	})
+	if err != nil {
+		t.Fatalf("Bad dates should not fail the search; %v", err)
+	}
+	if len(result.Entries) != 2 {
+		t.Fatalf("Wrong number of results; got %d expected %d", len(result.Entries), 2)
+	}
+
+	good := result.Entries[0]
+	if len(good.Meta.Warnings) != 0 {
+		t.Errorf("Unexpected warnings; got %v", good.Meta.Warnings)
+	}
+	if expected := time.Date(2017, time.March, 5, 9, 7, 0, 0, time.UTC); !good.Meta.Dates.Published.Equal(expected) {
+		t.Errorf("Wrong published date; got %v expected %v", good.Meta.Dates.Published, expected)
+	}
+	if good.Meta.Dates.Usenet.Unix() != 1488704820 || good.Content.Aired().Day() != 27 {
+		t.Errorf("Wrong usenet or air date; got %v, %v", good.Meta.Dates.Usenet, good.Content.Aired())
+	}
+
+	bad := result.Entries[1]
+	if len(bad.Meta.Warnings) != 2 {
+		t.Fatalf("Wrong number of warnings; got %v", bad.Meta.Warnings)
+	}
+	if bad.Meta.Warnings[0].Field != "pubDate" || bad.Meta.Warnings[1].Field != "tvairdate" {
+		t.Errorf("Wrong warning fields; got %v", bad.Meta.Warnings)
+	}
+	if !bad.Meta.Dates.Published.IsZero() || bad.Meta.Dates.Usenet.Day() != 12 {
+		t.Errorf("Wrong dates; got %v, %v", bad.Meta.Dates.Published, bad.Meta.Dates.Usenet)
+	}
+}
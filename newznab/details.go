@@ -0,0 +1,106 @@
+package newznab
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/url"
+
+	"github.com/smquartz/errors"
+)
+
+// API function names for functions relating to individual entries
+const (
+	FunctionDetails = "details"
+	FunctionGetNFO  = "getnfo"
+)
+
+// Details fetches the current version of the entry with the given ID from the
+// indexer, including every extended attribute.  It returns an *APIError with
+// ErrorCodeNoSuchItem if the indexer returns no entry.
+func (c *Client) Details(id EntryID) (Entry, error) {
+	return c.DetailsContext(context.Background(), id)
+}
+
+// DetailsContext is like Details, but performs its request with the given
+// context
+func (c *Client) DetailsContext(ctx context.Context, id EntryID) (Entry, error) {
+	u := c.buildURL(ModePathAPI, url.Values{
+		"t":      []string{FunctionDetails},
+		"id":     []string{id.APIValue()},
+		"apikey": []string{c.APIKey},
+	})
+	_, entries, err := c.feedFromURL(ctx, u, SearchOptions{})
+	if err != nil {
+		return Entry{}, err
+	}
+	if len(entries) == 0 {
+		return Entry{}, &APIError{Code: ErrorCodeNoSuchItem, Description: "no details returned for " + id.String()}
+	}
+	return entries[0], nil
+}
+
+// Refresh replaces the Entry with its current version from the indexer, e.g.
+// to update its number of grabs or comments.  Information populated since the
+// Entry was fetched, such as its Comments or File context, is discarded.
+func (e *Entry) Refresh(c *Client) error {
+	return e.RefreshContext(context.Background(), c)
+}
+
+// RefreshContext is like Refresh, but performs its request with the given
+// context
+func (e *Entry) RefreshContext(ctx context.Context, c *Client) error {
+	refreshed, err := c.DetailsContext(ctx, e.Meta.ID)
+	if err != nil {
+		return err
+	}
+	if refreshed.Meta.ID.IsZero() {
+		refreshed.Meta.ID = e.Meta.ID
+	}
+	*e = refreshed
+	return nil
+}
+
+// rawNFO describes the XML response format of getnfo, for indexers that do
+// not support returning the NFO as plain text
+type rawNFO struct {
+	Channel struct {
+		Items []struct {
+			Description string `xml:"description"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+// NFO fetches the NFO text of the entry with the given ID.  It returns an
+// *APIError with ErrorCodeNoSuchItem if the entry has no NFO.
+func (c *Client) NFO(id EntryID) (string, error) {
+	return c.NFOContext(context.Background(), id)
+}
+
+// NFOContext is like NFO, but performs its request with the given
+// context
+func (c *Client) NFOContext(ctx context.Context, id EntryID) (string, error) {
+	data, err := c.callAPI(ctx, FunctionGetNFO, url.Values{
+		"id":  []string{id.APIValue()},
+		"raw": []string{"1"},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// indexers that ignore raw return the NFO wrapped in an RSS feed
+	if trimmed := bytes.TrimSpace(data); bytes.HasPrefix(trimmed, []byte("<?xml")) || bytes.HasPrefix(trimmed, []byte("<rss")) {
+		rsp := new(rawNFO)
+		if err = xml.Unmarshal(trimmed, rsp); err != nil {
+			return "", errors.Wrapf(err, "error unmarshalling NFO", 1)
+		}
+		if len(rsp.Channel.Items) == 0 {
+			return "", &APIError{Code: ErrorCodeNoSuchItem, Description: "no NFO returned for " + id.String()}
+		}
+		return rsp.Channel.Items[0].Description, nil
+	}
+	if len(data) == 0 {
+		return "", &APIError{Code: ErrorCodeNoSuchItem, Description: "no NFO returned for " + id.String()}
+	}
+	return string(data), nil
+}
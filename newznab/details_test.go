@@ -0,0 +1,74 @@
+package newznab
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDetails(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+
+	entry, err := client.Details(EntryID("85ae3c25b68a6f1870bc7f732b939045"))
+	if err != nil {
+		t.Fatalf("Details failed; %v", err)
+	}
+	if entry.General.Title != "Bones.S10E21.DVDRip.X264-REWARD" {
+		t.Errorf("Wrong title; got %v", entry.General.Title)
+	}
+	if entry.Meta.Grabs != 61 || entry.Meta.Comments.Number != 2 {
+		t.Errorf("Wrong grabs or comments; got %d, %d", entry.Meta.Grabs, entry.Meta.Comments.Number)
+	}
+
+	_, err = client.Details(EntryID("00000000000000000000000000000000"))
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != ErrorCodeNoSuchItem {
+		t.Errorf("Details of a missing entry should return ErrorCodeNoSuchItem; got %v", err)
+	}
+}
+
+func TestEntryRefresh(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+
+	results, err := client.SearchWithTVRage([]Category{CategoryTVSD}, 2870, 10, 1)
+	if err != nil {
+		t.Fatalf("Failed to search mock indexer; %v", err)
+	}
+	entry := results[1]
+	if entry.Meta.Grabs != 54 {
+		t.Fatalf("Wrong grabs before refresh; got %d expected %d", entry.Meta.Grabs, 54)
+	}
+	if err = entry.RefreshContext(context.Background(), client); err != nil {
+		t.Fatalf("Refresh failed; %v", err)
+	}
+	if entry.Meta.Grabs != 61 {
+		t.Errorf("Wrong grabs after refresh; got %d expected %d", entry.Meta.Grabs, 61)
+	}
+	if entry.Meta.ID.APIValue() != "85ae3c25b68a6f1870bc7f732b939045" {
+		t.Errorf("Refresh changed the entry ID; got %v", entry.Meta.ID)
+	}
+}
+
+func TestNFO(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+
+	nfo, err := client.NFO(EntryID("85ae3c25b68a6f1870bc7f732b939045"))
+	if err != nil {
+		t.Fatalf("NFO failed; %v", err)
+	}
+	if !strings.Contains(nfo, "Bones.S10E21.DVDRip.X264-REWARD") || !strings.Contains(nfo, "\r\n") {
+		t.Errorf("Wrong plain text NFO; got %q", nfo)
+	}
+
+	nfo, err = client.NFO(EntryID("85ae5c0ba510f394b05cf0a0e9560f8c"))
+	if err != nil {
+		t.Fatalf("NFO failed; %v", err)
+	}
+	if nfo != "REWARD PRESENTS Bones.S10E20.DVDRip.X264-REWARD" {
+		t.Errorf("Wrong RSS wrapped NFO; got %q", nfo)
+	}
+}
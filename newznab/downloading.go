@@ -1,20 +1,26 @@
 package newznab
 
 import (
+	"context"
 	"net/url"
-	"strings"
 )
 
 // EntryDownloadURL returns the URL to download the entry from
 func (c *Client) EntryDownloadURL(entry Entry) *url.URL {
 	return c.buildURL(ModePathAPI, url.Values{
 		"t":      []string{"get"},
-		"id":     []string{strings.Replace(entry.Meta.ID.String(), "-", "", -1)},
+		"id":     []string{entry.Meta.ID.APIValue()},
 		"apikey": []string{c.APIKey},
 	})
 }
 
 // DownloadEntry returns the bytes of the actual NZB or other file for the given entry
 func (c *Client) DownloadEntry(entry Entry) ([]byte, error) {
-	return c.getURLResponseBody(c.EntryDownloadURL(entry))
+	return c.DownloadEntryContext(context.Background(), entry)
+}
+
+// DownloadEntryContext is like DownloadEntry, but performs its request with
+// the given context
+func (c *Client) DownloadEntryContext(ctx context.Context, entry Entry) ([]byte, error) {
+	return c.getURLResponseBody(ctx, RequestDownload, c.EntryDownloadURL(entry))
 }
@@ -3,8 +3,6 @@ package newznab
 import (
 	"net/url"
 	"time"
-
-	"github.com/satori/go.uuid"
 )
 
 // Source describes information relating to the source of an entry
@@ -27,7 +25,7 @@ type EntryDates struct {
 // content it describes
 type EntryMeta struct {
 	// entry's GUID
-	ID uuid.UUID
+	ID EntryID
 	// entry dates
 	Dates EntryDates
 	// information relating to the source of the entry
@@ -36,6 +34,9 @@ type EntryMeta struct {
 	Comments Comments
 	// number of times the newznab entry has been accessed
 	Grabs uint64
+	// values in the entry that could not be parsed, such as malformed dates;
+	// the corresponding fields are left zero
+	Warnings []ParseWarning
 }
 
 // EntryGeneral describes general information for an Entry
@@ -52,7 +53,10 @@ type EntryGeneral struct {
 // the newznab entry, such as genre, category, etc.
 type EntryCategorisation struct {
 	// newznab categories that the entry belongs to
-	Category []string
+	Category []Category
+	// categories as reported by the indexer, before being mapped to standard
+	// categories; only set when the Client maps categories
+	IndexerCategory []Category
 	// info string for the newznab entry
 	Info string
 	// genre that the content of the newznab entry belongs to
@@ -71,6 +75,14 @@ type Entry struct {
 	Content Content
 	// information relating to the file itself that the newznab entry corresponds to
 	File File
+	// audio and video properties of the release, and other metadata given in
+	// extended attributes
+	Media MediaInfo
+	// every newznab or torznab attribute of the entry, including any not
+	// otherwise parsed, such as indexer specific extensions
+	Attributes Attributes
+	// standard RSS fields of the item the entry was parsed from
+	Item Item
 }
 
 // Entries is simply a []Entry slice
@@ -0,0 +1,80 @@
+package newznab
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// EntryID identifies an Entry on the indexer it was retrieved from.  It is
+// opaque, and holds the GUID exactly as given by the indexer; newznab
+// indexers use 32 character hex strings, but torznab indexers commonly use
+// URLs or arbitrary strings.  EntryIDs may be compared with == and used as
+// map keys.
+type EntryID string
+
+// String returns the EntryID as given by the indexer
+func (id EntryID) String() string { return string(id) }
+
+// IsZero returns whether the EntryID is empty
+func (id EntryID) IsZero() bool { return id == "" }
+
+// APIValue returns the form of the EntryID used for the id parameter of API
+// requests, such as t=get and t=comments.  Dashed UUIDs have their dashes
+// removed.  URLs are reduced to their id or guid query parameter, or to their
+// final path segment if it is a newznab GUID, e.g.
+// https://indexer.example/details/85ae3c25b68a6f1870bc7f732b939045; other
+// URLs, and anything else, are returned as is.
+func (id EntryID) APIValue() string {
+	s := string(id)
+	if u, err := url.Parse(s); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		query := u.Query()
+		for _, key := range []string{"id", "guid"} {
+			if value := query.Get(key); value != "" {
+				return value
+			}
+		}
+		if base := path.Base(u.Path); isNewznabGUID(base) {
+			return base
+		}
+		return s
+	}
+	if isDashedUUID(s) {
+		return strings.Replace(s, "-", "", -1)
+	}
+	return s
+}
+
+// isNewznabGUID returns whether s is a GUID in the 32 character hex form used
+// by newznab indexers
+func isNewznabGUID(s string) bool {
+	if len(s) != 32 {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isDashedUUID returns whether s is a UUID in its canonical dashed form
+func isDashedUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, r := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if r != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+				return false
+			}
+		}
+	}
+	return true
+}
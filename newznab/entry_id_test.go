@@ -0,0 +1,51 @@
+package newznab
+
+import (
+	"context"
+	"testing"
+)
+
+func TestEntryIDAPIValue(t *testing.T) {
+	cases := map[EntryID]string{
+		"bcdbf3f1e7a1ef964527f1d40d5ec639":                           "bcdbf3f1e7a1ef964527f1d40d5ec639",
+		"bcdbf3f1-e7a1-ef96-4527-f1d40d5ec639":                       "bcdbf3f1e7a1ef964527f1d40d5ec639",
+		"https://tracker.example/torrents/12345":                     "https://tracker.example/torrents/12345",
+		"https://tracker.example/details.php?id=123":                 "123",
+		"https://tracker.example/details.php?id=456&hit=1":           "456",
+		"https://tracker.example/torrent?guid=abc-def":               "abc-def",
+		"https://dognzb.cr/details/85ae3c25b68a6f1870bc7f732b939045": "85ae3c25b68a6f1870bc7f732b939045",
+		"https://tracker.example/":                                   "https://tracker.example/",
+		"tracker-release-12345":                                      "tracker-release-12345",
+		"magnet:?xt=urn:btih:08ada5a7a6183aae1e09d831df6748d5":       "magnet:?xt=urn:btih:08ada5a7a6183aae1e09d831df6748d5",
+	}
+	for id, expected := range cases {
+		if got := id.APIValue(); got != expected {
+			t.Errorf("EntryID(%q).APIValue() = %q; expected %q", string(id), got, expected)
+		}
+	}
+}
+
+func TestEntryIDFromGUIDElement(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+
+	result, err := client.Do(context.Background(), SearchRequest{
+		Function:   FunctionSearch,
+		Query:      "Sintel",
+		Categories: []Category{CategoryTVAll},
+	})
+	if err != nil {
+		t.Fatalf("Failed to search mock indexer; %v", err)
+	}
+	if len(result.Entries) != 2 {
+		t.Fatalf("Wrong number of results; got %d expected %d", len(result.Entries), 2)
+	}
+	if id := result.Entries[0].Meta.ID; id != EntryID("5a1b9c3d7e2f4a6b8c0d1e2f3a4b5c6d") {
+		t.Errorf("ID should be taken from the guid element; got %q", id)
+	}
+
+	seen := map[EntryID]bool{result.Entries[0].Meta.ID: true}
+	if !seen[EntryID("5a1b9c3d7e2f4a6b8c0d1e2f3a4b5c6d")] {
+		t.Errorf("EntryID should be usable as a map key")
+	}
+}
@@ -0,0 +1,240 @@
+package newznab
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/smquartz/errors"
+)
+
+// EpisodeKind describes how an EpisodeSpec addresses episodes of a series
+type EpisodeKind int
+
+// EpisodeKind constants
+const (
+	// no episode information
+	EpisodeNone EpisodeKind = iota
+	// one or more episodes by season and episode number, e.g. S01E02-E04
+	EpisodeStandard
+	// every episode of a season
+	EpisodeSeasonPack
+	// the episode that aired on a given date, as used by daily shows
+	EpisodeDaily
+	// an episode numbered from the start of the series rather than the
+	// season, as commonly used for anime
+	EpisodeAbsolute
+)
+
+// EpisodeSpec identifies an episode, range of episodes or season of a series
+// in any of the forms newznab indexers use.  Construct one with NewEpisode,
+// NewEpisodeRange, NewSeasonPack, NewDailyEpisode or NewAbsoluteEpisode, or
+// parse season and episode values with ParseEpisodeSpec.
+type EpisodeSpec struct {
+	// how the episodes are addressed
+	Kind EpisodeKind
+	// number of the season; the year for daily episodes
+	Season uint
+	// number of the first episode; the absolute number for absolute
+	// episodes
+	Episode uint
+	// number of the last episode of a range; equal to Episode for a single
+	// episode
+	LastEpisode uint
+	// number of episodes in the season, if given, e.g. 12 for "E1/12"
+	Total uint
+	// air date of daily episodes
+	AirDate time.Time
+}
+
+// NewEpisode returns an EpisodeSpec for a single episode of a season
+func NewEpisode(season, episode uint) EpisodeSpec {
+	return EpisodeSpec{Kind: EpisodeStandard, Season: season, Episode: episode, LastEpisode: episode}
+}
+
+// NewEpisodeRange returns an EpisodeSpec for the episodes first to last
+// inclusive of a season
+func NewEpisodeRange(season, first, last uint) EpisodeSpec {
+	if last < first {
+		first, last = last, first
+	}
+	return EpisodeSpec{Kind: EpisodeStandard, Season: season, Episode: first, LastEpisode: last}
+}
+
+// NewSeasonPack returns an EpisodeSpec for every episode of a season
+func NewSeasonPack(season uint) EpisodeSpec {
+	return EpisodeSpec{Kind: EpisodeSeasonPack, Season: season}
+}
+
+// NewDailyEpisode returns an EpisodeSpec for the episode that aired on the
+// given date
+func NewDailyEpisode(date time.Time) EpisodeSpec {
+	year, month, day := date.Date()
+	return EpisodeSpec{
+		Kind:    EpisodeDaily,
+		Season:  uint(year),
+		AirDate: time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// NewAbsoluteEpisode returns an EpisodeSpec for an episode by its number from
+// the start of the series
+func NewAbsoluteEpisode(number uint) EpisodeSpec {
+	return EpisodeSpec{Kind: EpisodeAbsolute, Episode: number, LastEpisode: number}
+}
+
+// IsZero returns whether the EpisodeSpec holds no episode information
+func (s EpisodeSpec) IsZero() bool { return s.Kind == EpisodeNone }
+
+// IsRange returns whether the EpisodeSpec covers more than one numbered
+// episode
+func (s EpisodeSpec) IsRange() bool {
+	return (s.Kind == EpisodeStandard || s.Kind == EpisodeAbsolute) && s.LastEpisode > s.Episode
+}
+
+// Contains returns whether every episode of other is covered by s; for
+// instance a season pack contains each of its episodes, and S01E01-E03
+// contains S01E02
+func (s EpisodeSpec) Contains(other EpisodeSpec) bool {
+	switch s.Kind {
+	case EpisodeSeasonPack:
+		return other.Season == s.Season && (other.Kind == EpisodeStandard || other.Kind == EpisodeSeasonPack)
+	case EpisodeStandard:
+		return other.Kind == EpisodeStandard && other.Season == s.Season &&
+			other.Episode >= s.Episode && other.LastEpisode <= s.LastEpisode
+	case EpisodeAbsolute:
+		return other.Kind == EpisodeAbsolute && other.Episode >= s.Episode && other.LastEpisode <= s.LastEpisode
+	case EpisodeDaily:
+		return other.Kind == EpisodeDaily && other.AirDate.Equal(s.AirDate)
+	}
+	return false
+}
+
+// String returns the EpisodeSpec in the conventional scene form, e.g.
+// "S01E02", "S01E02-E04", "S01", "2017-05-17" or "#1043"
+func (s EpisodeSpec) String() string {
+	switch s.Kind {
+	case EpisodeStandard:
+		if s.IsRange() {
+			return fmt.Sprintf("S%02dE%02d-E%02d", s.Season, s.Episode, s.LastEpisode)
+		}
+		return fmt.Sprintf("S%02dE%02d", s.Season, s.Episode)
+	case EpisodeSeasonPack:
+		return fmt.Sprintf("S%02d", s.Season)
+	case EpisodeDaily:
+		return s.AirDate.Format("2006-01-02")
+	case EpisodeAbsolute:
+		if s.IsRange() {
+			return fmt.Sprintf("#%d-%d", s.Episode, s.LastEpisode)
+		}
+		return fmt.Sprintf("#%d", s.Episode)
+	}
+	return ""
+}
+
+// searchParams returns the season and ep search parameters that select the
+// EpisodeSpec.  Newznab cannot express a range of episodes, so a range
+// searches its whole season; Contains may be used to filter the results.  An
+// absolute range has no season to search, so neither parameter is returned;
+// SearchRequest.Validate rejects such requests.
+func (s EpisodeSpec) searchParams() (season, episode string) {
+	switch s.Kind {
+	case EpisodeStandard:
+		season = strconv.FormatUint(uint64(s.Season), 10)
+		if !s.IsRange() {
+			episode = strconv.FormatUint(uint64(s.Episode), 10)
+		}
+	case EpisodeSeasonPack:
+		season = strconv.FormatUint(uint64(s.Season), 10)
+	case EpisodeDaily:
+		season = s.AirDate.Format("2006")
+		episode = s.AirDate.Format("01/02")
+	case EpisodeAbsolute:
+		if !s.IsRange() {
+			episode = strconv.FormatUint(uint64(s.Episode), 10)
+		}
+	}
+	return season, episode
+}
+
+var (
+	// e.g. "1/12"; a date when the season is a year, otherwise an episode
+	// and the number of episodes in the season
+	episodeFractionRegexp = regexp.MustCompile(`^(\d+)/(\d+)$`)
+	// e.g. "2-4", "E02-E04" or "E02E03"
+	episodeRangeRegexp = regexp.MustCompile(`^(\d+)(?:\s*-\s*E?|E)(\d+)$`)
+	// e.g. "2017-05-17" or "2017/05/17"
+	episodeDateRegexp = regexp.MustCompile(`^(\d{4})[-/.](\d{1,2})[-/.](\d{1,2})$`)
+)
+
+// ParseEpisodeSpec parses the season and episode values of a newznab entry
+// or search, e.g. "S01" and "E02", "1" and "2-4", "2017" and "05/17", or ""
+// and "1043".  An episode without a season is taken to be absolutely
+// numbered, and a season without an episode to be a season pack.
+func ParseEpisodeSpec(season, episode string) (EpisodeSpec, error) {
+	season = strings.TrimSpace(season)
+	episode = strings.TrimLeft(strings.ToUpper(strings.TrimSpace(episode)), "E")
+
+	if m := episodeDateRegexp.FindStringSubmatch(episode); m != nil {
+		return parseDailyEpisode(m[1], m[2], m[3], episode)
+	}
+
+	var spec EpisodeSpec
+	hasSeason := season != ""
+	if hasSeason {
+		parsed, err := strconv.ParseUint(strings.TrimLeft(strings.ToUpper(season), "S"), 10, 64)
+		if err != nil {
+			return EpisodeSpec{}, errors.Wrapf(err, "error parsing season number: %v", 1, season)
+		}
+		spec.Season = uint(parsed)
+	}
+
+	if episode == "" {
+		if !hasSeason {
+			return EpisodeSpec{}, nil
+		}
+		spec.Kind = EpisodeSeasonPack
+		return spec, nil
+	}
+
+	spec.Kind = EpisodeStandard
+	if !hasSeason {
+		spec.Kind = EpisodeAbsolute
+	}
+	if m := episodeFractionRegexp.FindStringSubmatch(episode); m != nil {
+		if spec.Season >= 1900 {
+			return parseDailyEpisode(strconv.FormatUint(uint64(spec.Season), 10), m[1], m[2], episode)
+		}
+		number, _ := strconv.ParseUint(m[1], 10, 64)
+		total, _ := strconv.ParseUint(m[2], 10, 64)
+		spec.Episode, spec.LastEpisode, spec.Total = uint(number), uint(number), uint(total)
+		return spec, nil
+	}
+	if m := episodeRangeRegexp.FindStringSubmatch(episode); m != nil {
+		first, _ := strconv.ParseUint(m[1], 10, 64)
+		last, _ := strconv.ParseUint(m[2], 10, 64)
+		if last < first {
+			first, last = last, first
+		}
+		spec.Episode, spec.LastEpisode = uint(first), uint(last)
+		return spec, nil
+	}
+	number, err := strconv.ParseUint(episode, 10, 64)
+	if err != nil {
+		return EpisodeSpec{}, errors.Wrapf(err, "error parsing episode number: %v", 1, episode)
+	}
+	spec.Episode, spec.LastEpisode = uint(number), uint(number)
+	return spec, nil
+}
+
+// parseDailyEpisode returns the EpisodeSpec of a daily episode from the
+// components of its air date
+func parseDailyEpisode(year, month, day, raw string) (EpisodeSpec, error) {
+	date, err := time.Parse("2006-1-2", year+"-"+strings.TrimLeft(month, "0")+"-"+strings.TrimLeft(day, "0"))
+	if err != nil {
+		return EpisodeSpec{}, errors.Wrapf(err, "error parsing episode air date: %v", 1, raw)
+	}
+	return NewDailyEpisode(date), nil
+}
@@ -0,0 +1,140 @@
+package newznab
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseEpisodeSpec(t *testing.T) {
+	cases := []struct {
+		season, episode string
+		expected        EpisodeSpec
+		valid           bool
+	}{
+		{"1", "2", NewEpisode(1, 2), true},
+		{"S01", "E02", NewEpisode(1, 2), true},
+		{"S01", "E1/12", EpisodeSpec{Kind: EpisodeStandard, Season: 1, Episode: 1, LastEpisode: 1, Total: 12}, true},
+		{"2", "2-4", NewEpisodeRange(2, 2, 4), true},
+		{"2", "E04-E02", NewEpisodeRange(2, 2, 4), true},
+		{"S02", "E02E03", NewEpisodeRange(2, 2, 3), true},
+		{"S03", "", NewSeasonPack(3), true},
+		{"2017", "05/17", NewDailyEpisode(time.Date(2017, time.May, 17, 0, 0, 0, 0, time.UTC)), true},
+		{"", "2017-05-17", NewDailyEpisode(time.Date(2017, time.May, 17, 0, 0, 0, 0, time.UTC)), true},
+		{"", "1043", NewAbsoluteEpisode(1043), true},
+		{"", "", EpisodeSpec{}, true},
+		{"2017", "13/45", EpisodeSpec{}, false},
+		{"one", "2", EpisodeSpec{}, false},
+		{"1", "pilot", EpisodeSpec{}, false},
+	}
+	for _, c := range cases {
+		spec, err := ParseEpisodeSpec(c.season, c.episode)
+		if c.valid != (err == nil) {
+			t.Errorf("ParseEpisodeSpec(%q, %q) returned unexpected error state; %v", c.season, c.episode, err)
+		} else if spec != c.expected {
+			t.Errorf("ParseEpisodeSpec(%q, %q) returned wrong spec; got %+v expected %+v", c.season, c.episode, spec, c.expected)
+		}
+	}
+}
+
+func TestEpisodeSpecString(t *testing.T) {
+	cases := []struct {
+		spec     EpisodeSpec
+		expected string
+	}{
+		{NewEpisode(1, 2), "S01E02"},
+		{NewEpisodeRange(2, 1, 3), "S02E01-E03"},
+		{NewSeasonPack(3), "S03"},
+		{NewDailyEpisode(time.Date(2017, time.May, 17, 23, 0, 0, 0, time.UTC)), "2017-05-17"},
+		{NewAbsoluteEpisode(1043), "#1043"},
+		{EpisodeSpec{}, ""},
+	}
+	for _, c := range cases {
+		if s := c.spec.String(); s != c.expected {
+			t.Errorf("Wrong string for %+v; got %q expected %q", c.spec, s, c.expected)
+		}
+	}
+}
+
+func TestEpisodeSpecContains(t *testing.T) {
+	cases := []struct {
+		spec, other EpisodeSpec
+		expected    bool
+	}{
+		{NewSeasonPack(2), NewEpisode(2, 5), true},
+		{NewSeasonPack(2), NewEpisodeRange(2, 1, 3), true},
+		{NewSeasonPack(2), NewEpisode(3, 5), false},
+		{NewEpisodeRange(2, 1, 3), NewEpisode(2, 2), true},
+		{NewEpisodeRange(2, 1, 3), NewEpisodeRange(2, 2, 4), false},
+		{NewEpisode(2, 2), NewSeasonPack(2), false},
+		{NewAbsoluteEpisode(1043), NewAbsoluteEpisode(1043), true},
+		{NewAbsoluteEpisode(1043), NewEpisode(1, 1043), false},
+		{NewDailyEpisode(time.Date(2017, time.May, 17, 0, 0, 0, 0, time.UTC)), NewDailyEpisode(time.Date(2017, time.May, 17, 12, 0, 0, 0, time.UTC)), true},
+	}
+	for _, c := range cases {
+		if contains := c.spec.Contains(c.other); contains != c.expected {
+			t.Errorf("%v.Contains(%v) returned %v expected %v", c.spec, c.other, contains, c.expected)
+		}
+	}
+}
+
+func TestSearchRequestEpisodeSpec(t *testing.T) {
+	cases := []struct {
+		req      SearchRequest
+		expected string
+	}{
+		{SearchRequest{Function: FunctionTVSearch, TVDBID: 75682, EpisodeSpec: NewEpisode(10, 1)}, "ep=1&season=10&t=tvsearch&tvdbid=75682"},
+		{SearchRequest{Function: FunctionTVSearch, TVDBID: 75682, EpisodeSpec: NewSeasonPack(10)}, "season=10&t=tvsearch&tvdbid=75682"},
+		{SearchRequest{Function: FunctionTVSearch, TVDBID: 75682, EpisodeSpec: NewEpisodeRange(10, 1, 3)}, "season=10&t=tvsearch&tvdbid=75682"},
+		{SearchRequest{Function: FunctionTVSearch, TVDBID: 71256, EpisodeSpec: NewDailyEpisode(time.Date(2017, time.May, 17, 0, 0, 0, 0, time.UTC))}, "ep=05%2F17&season=2017&t=tvsearch&tvdbid=71256"},
+		{SearchRequest{Function: FunctionTVSearch, Query: "One Piece", EpisodeSpec: NewAbsoluteEpisode(1043)}, "ep=1043&q=One+Piece&t=tvsearch"},
+		{SearchRequest{Function: FunctionTVSearch, Season: "1", Episode: "2", EpisodeSpec: NewSeasonPack(3)}, "ep=2&season=1&t=tvsearch"},
+	}
+	for k, c := range cases {
+		if encoded := c.req.Values().Encode(); encoded != c.expected {
+			t.Errorf("Case %d has wrong query parameters; got %v expected %v", k, encoded, c.expected)
+		}
+	}
+}
+
+func TestSearchEpisodeSpecs(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+
+	result, err := client.Do(context.Background(), SearchRequest{
+		Query:      "Episodes",
+		Categories: []Category{CategoryTVAll},
+	})
+	if err != nil {
+		t.Fatalf("Failed to search mock indexer; %v", err)
+	}
+	expected := []string{"2017-05-17", "#1043", "S02E01-E03", "S03", "S01E01", ""}
+	if len(result.Entries) != len(expected) {
+		t.Fatalf("Wrong number of results; got %d expected %d", len(result.Entries), len(expected))
+	}
+	for k, entry := range result.Entries {
+		tv, ok := entry.Content.(*TV)
+		if !ok {
+			t.Errorf("Entry %d content should be *TV; got %T", k, entry.Content)
+			continue
+		}
+		if s := tv.EpisodeSpec.String(); s != expected[k] {
+			t.Errorf("Entry %d has wrong episode; got %v expected %v", k, s, expected[k])
+		}
+	}
+
+	frieren := result.Entries[4].Content.(*TV)
+	if frieren.Episode != 1 || frieren.EpisodeSpec.Total != 28 {
+		t.Errorf("Wrong episode of season; got %d of %d", frieren.Episode, frieren.EpisodeSpec.Total)
+	}
+
+	// an episode that cannot be parsed is kept in its raw form
+	special := result.Entries[5]
+	doctorWho := special.Content.(*TV)
+	if doctorWho.RawSeason != "S04" || doctorWho.RawEpisode != "special" || doctorWho.Season != 4 {
+		t.Errorf("Raw season and episode should be kept; got %q, %q, %d", doctorWho.RawSeason, doctorWho.RawEpisode, doctorWho.Season)
+	}
+	if len(special.Meta.Warnings) != 1 || special.Meta.Warnings[0].Field != "episode" {
+		t.Errorf("Unparseable episode should produce one warning; got %v", special.Meta.Warnings)
+	}
+}
@@ -0,0 +1,166 @@
+package newznab
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ErrorCode is an error code returned by a newznab API in an error response
+type ErrorCode int
+
+// Newznab error code constants
+const (
+	// ErrorCodeIncorrectCredentials is returned for an invalid API key or
+	// user credentials
+	ErrorCodeIncorrectCredentials ErrorCode = 100
+	// ErrorCodeAccountSuspended is returned when the account has been suspended
+	ErrorCodeAccountSuspended ErrorCode = 101
+	// ErrorCodeInsufficientPrivileges is returned when the account is not
+	// authorised to perform the request
+	ErrorCodeInsufficientPrivileges ErrorCode = 102
+	// ErrorCodeRegistrationDenied is returned when registration is denied
+	ErrorCodeRegistrationDenied ErrorCode = 103
+	// ErrorCodeRegistrationsClosed is returned when registrations are closed
+	ErrorCodeRegistrationsClosed ErrorCode = 104
+	// ErrorCodeEmailTaken is returned when registering with an email address
+	// that is already in use
+	ErrorCodeEmailTaken ErrorCode = 105
+	// ErrorCodeEmailBadFormat is returned when registering with a malformed
+	// email address
+	ErrorCodeEmailBadFormat ErrorCode = 106
+	// ErrorCodeRegistrationFailed is returned when registration fails for
+	// another reason
+	ErrorCodeRegistrationFailed ErrorCode = 107
+	// ErrorCodeMissingParameter is returned when a required parameter is missing
+	ErrorCodeMissingParameter ErrorCode = 200
+	// ErrorCodeIncorrectParameter is returned when a parameter is missing or
+	// has an invalid value; indexers use it interchangeably with
+	// ErrorCodeMissingParameter
+	ErrorCodeIncorrectParameter ErrorCode = 201
+	// ErrorCodeNoSuchFunction is returned for an unknown API function
+	ErrorCodeNoSuchFunction ErrorCode = 202
+	// ErrorCodeFunctionNotAvailable is returned for an optional API function
+	// the indexer does not implement
+	ErrorCodeFunctionNotAvailable ErrorCode = 203
+	// ErrorCodeNoSuchItem is returned when the requested item does not exist
+	ErrorCodeNoSuchItem ErrorCode = 300
+	// ErrorCodeTooManyRequests is returned by some indexers when a rate limit
+	// has been exceeded
+	ErrorCodeTooManyRequests ErrorCode = 429
+	// ErrorCodeRequestLimitReached is returned when the daily API request
+	// limit has been reached
+	ErrorCodeRequestLimitReached ErrorCode = 500
+	// ErrorCodeDownloadLimitReached is returned when the daily download
+	// limit has been reached
+	ErrorCodeDownloadLimitReached ErrorCode = 501
+	// ErrorCodeUnknown is returned for an unspecified error
+	ErrorCodeUnknown ErrorCode = 900
+	// ErrorCodeAPIDisabled is returned when the API has been disabled
+	ErrorCodeAPIDisabled ErrorCode = 910
+)
+
+// IsCredentialsError returns whether the error code relates to the
+// credentials or privileges of the account used
+func (c ErrorCode) IsCredentialsError() bool {
+	return c >= ErrorCodeIncorrectCredentials && c <= ErrorCodeInsufficientPrivileges
+}
+
+// IsRegistrationError returns whether the error code relates to a failed
+// registration
+func (c ErrorCode) IsRegistrationError() bool {
+	return c >= ErrorCodeRegistrationDenied && c <= ErrorCodeRegistrationFailed
+}
+
+// IsParameterError returns whether the error code relates to a missing or
+// incorrect request parameter
+func (c ErrorCode) IsParameterError() bool {
+	return c == ErrorCodeMissingParameter || c == ErrorCodeIncorrectParameter
+}
+
+// IsLimitError returns whether the error code relates to a rate, request or
+// download limit having been exceeded
+func (c ErrorCode) IsLimitError() bool {
+	return c == ErrorCodeTooManyRequests || c == ErrorCodeRequestLimitReached || c == ErrorCodeDownloadLimitReached
+}
+
+// APIError describes an error response returned by a newznab API, e.g.
+// <error code="100" description="Incorrect user credentials"/>
+type APIError struct {
+	// newznab error code
+	Code ErrorCode
+	// description of the error provided by the indexer
+	Description string
+}
+
+// Error implements the error interface for APIError
+func (e *APIError) Error() string {
+	return fmt.Sprintf("newznab API error %d: %s", e.Code, e.Description)
+}
+
+// HTTPError describes a response from an indexer with a non-2xx HTTP status
+type HTTPError struct {
+	// HTTP status code of the response
+	StatusCode int
+	// HTTP status line of the response, e.g. "404 Not Found"
+	Status string
+	// URL that was requested, with credentials redacted
+	URL *url.URL
+	// headers of the response
+	Header http.Header
+	// body of the response
+	Body []byte
+}
+
+// Error implements the error interface for HTTPError
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("unexpected HTTP status %s from %v", e.Status, e.URL)
+}
+
+// rawError describes the XML response format for newznab errors
+type rawError struct {
+	XMLName     xml.Name `xml:"error"`
+	Code        int      `xml:"code,attr"`
+	Description string   `xml:"description,attr"`
+}
+
+// apiErrorFromResponseBody returns an *APIError if the given response body is
+// a newznab error response, and nil otherwise
+func apiErrorFromResponseBody(data []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			// not XML, or no root element; e.g. a torrent file
+			return nil
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if start.Name.Local != "error" {
+			return nil
+		}
+		raw := new(rawError)
+		if err := decoder.DecodeElement(raw, &start); err != nil {
+			return nil
+		}
+		return &APIError{Code: ErrorCode(raw.Code), Description: raw.Description}
+	}
+}
+
+// redactURL returns a copy of the given URL with credentials removed from its
+// query parameters, suitable for inclusion in errors
+func redactURL(u *url.URL) *url.URL {
+	redacted := *u
+	values := redacted.Query()
+	for _, key := range []string{"apikey", "r"} {
+		if values.Get(key) != "" {
+			values.Set(key, "REDACTED")
+		}
+	}
+	redacted.RawQuery = values.Encode()
+	return &redacted
+}
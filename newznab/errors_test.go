@@ -0,0 +1,129 @@
+package newznab
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+
+	_, err := client.SearchWithTVDB([]Category{CategoryTVSD}, 5678, 9, 2)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected an *APIError; got %v", err)
+	}
+	if apiErr.Code != ErrorCodeIncorrectCredentials {
+		t.Errorf("Wrong error code; got %d expected %d", apiErr.Code, ErrorCodeIncorrectCredentials)
+	}
+	if !apiErr.Code.IsCredentialsError() || apiErr.Code.IsLimitError() {
+		t.Errorf("Error code %d was misclassified", apiErr.Code)
+	}
+	if apiErr.Description != "Invalid API Key" {
+		t.Errorf("Wrong error description; got %v", apiErr.Description)
+	}
+}
+
+func TestHTTPError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("down for maintenance"))
+	}))
+	defer ts.Close()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse mock server URL")
+	}
+	client := &Client{HTTPClient: &http.Client{}, BaseURL: u, APIKey: "secret"}
+
+	_, err = client.SearchContext(context.Background(), url.Values{"t": []string{"search"}})
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("Expected an *HTTPError; got %v", err)
+	}
+	if httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Wrong status code; got %d", httpErr.StatusCode)
+	}
+	if string(httpErr.Body) != "down for maintenance" {
+		t.Errorf("Wrong body; got %q", httpErr.Body)
+	}
+	if strings.Contains(httpErr.Error(), "secret") {
+		t.Errorf("HTTPError leaked the API key: %v", httpErr)
+	}
+}
+
+func TestAPIErrorFromResponseBody(t *testing.T) {
+	cases := []struct {
+		body string
+		code ErrorCode
+	}{
+		{`<?xml version="1.0" encoding="UTF-8"?><error code="201" description="Incorrect parameter"/>`, ErrorCodeIncorrectParameter},
+		{`<error code="500" description="Request limit reached"/>`, ErrorCodeRequestLimitReached},
+		{`<rss version="2.0"><channel></channel></rss>`, 0},
+		{"d8:announce35:udp://tracker.example.com:80e", 0},
+	}
+	for _, c := range cases {
+		err := apiErrorFromResponseBody([]byte(c.body))
+		if c.code == 0 {
+			if err != nil {
+				t.Errorf("Expected no error for %q; got %v", c.body, err)
+			}
+			continue
+		}
+		apiErr, ok := err.(*APIError)
+		if !ok || apiErr.Code != c.code {
+			t.Errorf("Expected error code %d for %q; got %v", c.code, c.body, err)
+		}
+	}
+}
+
+func TestErrorsAsFromEntryPoints(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<error code="100" description="Invalid API Key"/>`))
+	}))
+	defer ts.Close()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse mock server URL")
+	}
+	client := &Client{HTTPClient: &http.Client{}, BaseURL: u, APIKey: "gibberish", Quota: NewQuota(0, 2)}
+	ctx := context.Background()
+	entry := Entry{}
+	entry.Meta.ID = EntryID("85ae3c25b68a6f1870bc7f732b939045")
+	torrent := Entry{File: &TorrentFile{DownloadURL: u}}
+
+	var apiErr *APIError
+	if err := entry.PopulateCommentsContext(ctx, client); !errors.As(err, &apiErr) {
+		t.Errorf("PopulateCommentsContext should return an *APIError; got %v", err)
+	}
+	if err := entry.PopulateFileContext(ctx, client); !errors.As(err, &apiErr) {
+		t.Errorf("PopulateFileContext should return an *APIError for a NZB; got %v", err)
+	}
+	if err := torrent.PopulateFileContext(ctx, client); !errors.As(err, &apiErr) {
+		t.Errorf("PopulateFileContext should return an *APIError for a torrent; got %v", err)
+	}
+	if _, err := client.SearchRSSUntilEntryIDContext(ctx, []Category{CategoryTVAll}, 10, EntryID(""), 1); !errors.As(err, &apiErr) {
+		t.Errorf("SearchRSSUntilEntryIDContext should return an *APIError; got %v", err)
+	}
+
+	// both downloads of the quota have been used
+	var quotaErr *QuotaExceededError
+	if err := entry.PopulateFileContext(ctx, client); !errors.As(err, &quotaErr) {
+		t.Errorf("PopulateFileContext should return a *QuotaExceededError; got %v", err)
+	}
+
+	err = Entries{entry}.Populate(ctx, client, PopulateOptions{Enrichments: EnrichComments | EnrichFile})
+	var populateErr PopulateError
+	if !errors.As(err, &populateErr) || populateErr.Enrichment != EnrichComments {
+		t.Errorf("Populate should return the PopulateError of each enrichment; got %v", err)
+	}
+	if !errors.As(err, &apiErr) || !errors.As(err, &quotaErr) {
+		t.Errorf("Populate should return the cause of each failed enrichment; got %v", err)
+	}
+}
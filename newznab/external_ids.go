@@ -0,0 +1,148 @@
+package newznab
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/smquartz/errors"
+)
+
+// IMDBID is the numeric part of an IMDb title ID, e.g. 816692 for tt0816692
+type IMDBID int64
+
+// ParseIMDBID parses an IMDb title ID, with or without its tt prefix and zero
+// padding, e.g. "tt0816692", "0816692" or "816692".  Indexers send zero, e.g.
+// "0000000", for an unknown title; it parses as the zero IMDBID.
+func ParseIMDBID(s string) (IMDBID, error) {
+	s = strings.TrimSpace(s)
+	digits := strings.TrimPrefix(strings.ToLower(s), "tt")
+	parsed, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil || parsed < 0 {
+		return 0, errors.Errorf("invalid IMDb ID: %v", s)
+	}
+	return IMDBID(parsed), nil
+}
+
+// String returns the ID in its canonical form, e.g. "tt0816692"
+func (id IMDBID) String() string { return "tt" + id.Digits() }
+
+// Digits returns the ID zero padded to at least seven digits, without its tt
+// prefix, e.g. "0816692"; this is the form expected by the imdbid search
+// parameter
+func (id IMDBID) Digits() string { return fmt.Sprintf("%07d", int64(id)) }
+
+// TVDBID is the ID of a series on TheTVDB
+type TVDBID int64
+
+// String returns the ID as a decimal string
+func (id TVDBID) String() string { return strconv.FormatInt(int64(id), 10) }
+
+// TVRageID is the ID of a series on TVRage.  TVRage has shut down, but some
+// indexers still report its IDs.
+type TVRageID int64
+
+// String returns the ID as a decimal string
+func (id TVRageID) String() string { return strconv.FormatInt(int64(id), 10) }
+
+// TMDBID is the ID of a movie or series on TheMovieDB
+type TMDBID int64
+
+// String returns the ID as a decimal string
+func (id TMDBID) String() string { return strconv.FormatInt(int64(id), 10) }
+
+// TVMazeID is the ID of a series on TVMaze
+type TVMazeID int64
+
+// String returns the ID as a decimal string
+func (id TVMazeID) String() string { return strconv.FormatInt(int64(id), 10) }
+
+// TraktID is the ID of a movie or series on Trakt
+type TraktID int64
+
+// String returns the ID as a decimal string
+func (id TraktID) String() string { return strconv.FormatInt(int64(id), 10) }
+
+// AniDBID is the ID of an anime on AniDB
+type AniDBID int64
+
+// String returns the ID as a decimal string
+func (id AniDBID) String() string { return strconv.FormatInt(int64(id), 10) }
+
+// DoubanID is the ID of a movie or series on Douban
+type DoubanID int64
+
+// String returns the ID as a decimal string
+func (id DoubanID) String() string { return strconv.FormatInt(int64(id), 10) }
+
+// ExternalIDs holds the IDs of content in external metadata databases.  Zero
+// values mean the ID is not known.
+type ExternalIDs struct {
+	// ID on IMDb
+	IMDBID IMDBID
+	// ID on TheTVDB
+	TVDBID TVDBID
+	// ID on TVRage
+	TVRageID TVRageID
+	// ID on TheMovieDB
+	TMDBID TMDBID
+	// ID on TVMaze
+	TVMazeID TVMazeID
+	// ID on Trakt
+	TraktID TraktID
+	// ID on AniDB
+	AniDBID AniDBID
+	// ID on Douban
+	DoubanID DoubanID
+}
+
+// IsZero returns whether no ID is known
+func (ids ExternalIDs) IsZero() bool { return ids == ExternalIDs{} }
+
+// set parses the value of an attribute holding an external ID
+func (ids *ExternalIDs) set(name, value string) error {
+	if name == "imdb" || name == "imdbid" {
+		id, err := ParseIMDBID(value)
+		if err != nil {
+			return err
+		}
+		ids.IMDBID = id
+		return nil
+	}
+	parsed, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "error parsing %v: %v", 1, name, value)
+	}
+	switch name {
+	case "tvdbid":
+		ids.TVDBID = TVDBID(parsed)
+	case "rageid":
+		ids.TVRageID = TVRageID(parsed)
+	case "tmdbid":
+		ids.TMDBID = TMDBID(parsed)
+	case "tvmazeid":
+		ids.TVMazeID = TVMazeID(parsed)
+	case "traktid":
+		ids.TraktID = TraktID(parsed)
+	case "anidbid":
+		ids.AniDBID = AniDBID(parsed)
+	case "doubanid":
+		ids.DoubanID = DoubanID(parsed)
+	default:
+		return errors.Errorf("encountered unknown external ID attribute %v: %v", name, value)
+	}
+	return nil
+}
+
+// ExternalIDs returns the external IDs of the Entry's content, if it is of a
+// type that has them
+func (e Entry) ExternalIDs() ExternalIDs {
+	switch content := e.Content.(type) {
+	case *TV:
+		return content.ExternalIDs
+	case *Movie:
+		return content.ExternalIDs
+	default:
+		return ExternalIDs{}
+	}
+}
@@ -0,0 +1,104 @@
+package newznab
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseIMDBID(t *testing.T) {
+	cases := []struct {
+		raw      string
+		expected IMDBID
+		valid    bool
+	}{
+		{"tt0816692", 816692, true},
+		{"TT0816692", 816692, true},
+		{"0816692", 816692, true},
+		{"816692", 816692, true},
+		{" tt22248376 ", 22248376, true},
+		{"", 0, false},
+		{"tt", 0, false},
+		{"nm0000123x", 0, false},
+		{"-816692", 0, false},
+		{"0", 0, true},
+		{"0000000", 0, true},
+	}
+	for _, c := range cases {
+		id, err := ParseIMDBID(c.raw)
+		if c.valid != (err == nil) {
+			t.Errorf("ParseIMDBID(%q) returned unexpected error state; %v", c.raw, err)
+		} else if id != c.expected {
+			t.Errorf("ParseIMDBID(%q) returned wrong ID; got %d expected %d", c.raw, id, c.expected)
+		}
+	}
+
+	if s := IMDBID(816692).String(); s != "tt0816692" {
+		t.Errorf("Wrong IMDB ID string; got %v expected %v", s, "tt0816692")
+	}
+	if s := IMDBID(22248376).Digits(); s != "22248376" {
+		t.Errorf("Wrong IMDB ID digits; got %v expected %v", s, "22248376")
+	}
+}
+
+func TestSearchRequestExternalIDs(t *testing.T) {
+	req := SearchRequest{
+		Function: FunctionTVSearch,
+		IMDBID:   22248376,
+		TVMazeID: 68305,
+		TMDBID:   209867,
+		TraktID:  198463,
+	}
+	expected := "imdbid=22248376&t=tvsearch&tmdbid=209867&traktid=198463&tvmazeid=68305"
+	if encoded := req.Values().Encode(); encoded != expected {
+		t.Errorf("Wrong query parameters; got %v expected %v", encoded, expected)
+	}
+}
+
+func TestSearchExternalIDs(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+
+	result, err := client.Do(context.Background(), SearchRequest{
+		Query:      "Frieren",
+		Categories: []Category{CategoryTVAll},
+	})
+	if err != nil {
+		t.Fatalf("Failed to search mock indexer; %v", err)
+	}
+	if len(result.Entries) != 3 {
+		t.Fatalf("Wrong number of results; got %d expected %d", len(result.Entries), 3)
+	}
+
+	if _, ok := result.Entries[0].Content.(*TV); !ok {
+		t.Fatalf("Content should be *TV; got %T", result.Entries[0].Content)
+	}
+	expected := ExternalIDs{
+		IMDBID:   22248376,
+		TVDBID:   424536,
+		TVMazeID: 68305,
+		TMDBID:   209867,
+		TraktID:  198463,
+		AniDBID:  17617,
+	}
+	if ids := result.Entries[0].ExternalIDs(); ids != expected {
+		t.Errorf("Wrong series IDs; got %+v expected %+v", ids, expected)
+	}
+
+	movie, ok := result.Entries[1].Content.(*Movie)
+	if !ok {
+		t.Fatalf("Content should be *Movie; got %T", result.Entries[1].Content)
+	}
+	if movie.IMDBID.String() != "tt0816692" || movie.TMDBID != 157336 {
+		t.Errorf("Wrong movie IDs; got %+v", movie.ExternalIDs)
+	}
+
+	// an unknown IMDb ID is left unset, and a malformed ID is a warning
+	// rather than a failure of the entry
+	unknown := result.Entries[2]
+	if ids := unknown.ExternalIDs(); ids != (ExternalIDs{TVMazeID: 68305}) {
+		t.Errorf("Wrong IDs for unknown IMDb and malformed TVDB IDs; got %+v", ids)
+	}
+	if len(unknown.Meta.Warnings) != 1 || unknown.Meta.Warnings[0].Field != "tvdbid" {
+		t.Errorf("Expected a single warning for tvdbid; got %v", unknown.Meta.Warnings)
+	}
+}
@@ -2,8 +2,11 @@ package newznab
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"io"
 	"net/url"
+	"time"
 
 	"github.com/smquartz/errors"
 	"github.com/smquartz/go-torznab/nzb"
@@ -29,14 +32,32 @@ type File interface {
 	Populate(c *Client, e *Entry) error
 }
 
+// ContextPopulator is an optional interface that may be implemented by a
+// File, to populate it with requests bound to a context.  Files that do not
+// implement it are populated with Populate, ignoring the context.
+type ContextPopulator interface {
+	// PopulateContext is like Populate, but performs its requests with the
+	// given context
+	PopulateContext(ctx context.Context, c *Client, e *Entry) error
+}
+
 // PopulateFile populates File.  If File is already set to a specific
 // implementation, that implementation's Populate() method will be called,
 // else, File will be set to a new instance of NZBFile, and its Populate()
 // method called.
 func (e *Entry) PopulateFile(c *Client) error {
+	return e.PopulateFileContext(context.Background(), c)
+}
+
+// PopulateFileContext is like PopulateFile, but performs its requests with the
+// given context if File implements ContextPopulator
+func (e *Entry) PopulateFileContext(ctx context.Context, c *Client) error {
 	if e.File == nil {
 		e.File = new(NZBFile)
 	}
+	if p, ok := e.File.(ContextPopulator); ok {
+		return p.PopulateContext(ctx, c, e)
+	}
 	return e.File.Populate(c, e)
 }
 
@@ -65,14 +86,20 @@ func (n *NZBFile) populateDownloadURL(c *Client, e *Entry) error {
 
 // Populate populates the NZBFile
 func (n *NZBFile) Populate(c *Client, e *Entry) error {
+	return n.PopulateContext(context.Background(), c, e)
+}
+
+// PopulateContext is like Populate, but performs its request with the given
+// context
+func (n *NZBFile) PopulateContext(ctx context.Context, c *Client, e *Entry) error {
 	err := n.populateDownloadURL(c, e)
 	if err != nil {
 		return errors.Wrapf(err, "error populating download URL", 1)
 	}
 
-	raw, err := c.getURLResponseBody(n.URL())
+	raw, err := c.getURLResponseBody(ctx, RequestDownload, n.URL())
 	if err != nil {
-		return errors.Wrapf(err, "error requesting NZB file", 1)
+		return fmt.Errorf("error requesting NZB file: %w", err)
 	}
 
 	parsedNZB, err := nzb.FromBytes(raw)
@@ -84,27 +111,75 @@ func (n *NZBFile) Populate(c *Client, e *Entry) error {
 	return nil
 }
 
-// TorrentFile is a File implementation that describes a torrent file
+// TorrentFile is a File implementation that describes a torrent file.  The
+// number of times a torrent has been grabbed is common to all entries, so is
+// recorded in the Entry's Meta.Grabs rather than here.
 type TorrentFile struct {
 	// size of the torrent contents in bytes
 	ContentsSize uint64
 	// number of seeders on the torrent
 	Seeders uint64
+	// number of leechers on the torrent
+	Leechers uint64
 	// number of peers on the torrent
 	Peers uint64
+	// number of files in the torrent
+	Files uint64
 	// SHA1 hash of the info part of the torrent
 	InfoHash []byte `json:"infohash,omitempty"`
+	// SHA256 hash of the info part of the torrent, for v2 torrents
+	InfoHashV2 []byte `json:"infohashv2,omitempty"`
+	// display name of the torrent
+	Name string
+	// tracker URLs advertised for the torrent
+	Trackers []string
+	// magnet URI for the torrent, as advertised by the indexer
+	MagnetURL *url.URL
+	// factor applied to the downloaded amount counted against the user's
+	// ratio; 0 means freeleech, 1 means the full amount is counted
+	DownloadVolumeFactor float64
+	// factor applied to the uploaded amount credited to the user's ratio;
+	// e.g. 2 means double upload
+	UploadVolumeFactor float64
+	// ratio the torrent must be seeded to
+	MinimumRatio float64
+	// time the torrent must be seeded for
+	MinimumSeedTime time.Duration
+	// tags applied to the torrent by the tracker, e.g. "freeleech" or
+	// "internal"
+	Tags []string
+	// metainfo parsed from the raw torrent file; nil until populated
+	Metainfo *Metainfo
 	// bytes of the raw torrent file
 	Raw []byte
 	// URL to download torrent file from
 	DownloadURL *url.URL
 }
 
+// NewTorrentFile returns a TorrentFile with volume factors set to 1, which is
+// what torznab specifies when the corresponding attributes are absent
+func NewTorrentFile() *TorrentFile {
+	return &TorrentFile{
+		DownloadVolumeFactor: 1,
+		UploadVolumeFactor:   1,
+	}
+}
+
+// IsFreeleech returns whether downloading the torrent does not count against
+// the user's ratio
+func (t TorrentFile) IsFreeleech() bool { return t.DownloadVolumeFactor == 0 }
+
 // Size returns the size of the torrent contents in bytes
 func (t TorrentFile) Size() uint64 { return t.ContentsSize }
 
-// URL returns a URL where the raw torrent file may be downloaded from
-func (t TorrentFile) URL() *url.URL { return t.DownloadURL }
+// URL returns a URL where the raw torrent file may be downloaded from.  If
+// the indexer only provides a magnet link, the magnet URI is returned instead.
+func (t TorrentFile) URL() *url.URL {
+	if t.DownloadURL != nil {
+		return t.DownloadURL
+	}
+	return t.Magnet()
+}
 
 // Bytes returns the bytes of the raw torrent file
 func (t TorrentFile) Bytes() ([]byte, error) { return t.Raw, nil }
@@ -113,14 +188,41 @@ func (t TorrentFile) Bytes() ([]byte, error) { return t.Raw, nil }
 func (t TorrentFile) BytesReader() (io.Reader, error) { return bytes.NewBuffer(t.Raw), nil }
 
 // Populate populates the TorrentFile, with
-// the information contained within the raw torrent file.
-func (t *TorrentFile) Populate(c *Client, e *Entry) (err error) {
-	if t.URL() == nil {
+// the information contained within the raw torrent file.  If the torrent is
+// only available as a magnet link, the TorrentFile is instead populated from
+// the magnet URI, as the metainfo cannot be fetched over HTTP.  If the indexer
+// advertised an infohash that does not match the torrent, an
+// *InfoHashMismatchError is returned and the TorrentFile is left unchanged.
+func (t *TorrentFile) Populate(c *Client, e *Entry) error {
+	return t.PopulateContext(context.Background(), c, e)
+}
+
+// PopulateContext is like Populate, but performs its request with the given
+// context
+func (t *TorrentFile) PopulateContext(ctx context.Context, c *Client, e *Entry) (err error) {
+	u := t.URL()
+	if u == nil {
 		return errors.Errorf("Empty download URL")
 	}
-	t.Raw, err = c.getURLResponseBody(t.URL())
+	if IsMagnetURL(u) {
+		m, err := ParseMagnet(u.String())
+		if err != nil {
+			return errors.Wrapf(err, "error parsing magnet URI", 1)
+		}
+		t.applyMagnet(m)
+		return nil
+	}
+	raw, err := c.getURLResponseBody(ctx, RequestDownload, u)
+	if err != nil {
+		return err
+	}
+	m, err := ParseMetainfo(raw)
 	if err != nil {
-		return errors.Wrap(err, 1)
+		return errors.Wrapf(err, "error parsing torrent file", 1)
+	}
+	if err = t.applyMetainfo(m); err != nil {
+		return err
 	}
+	t.Raw = raw
 	return nil
 }
@@ -0,0 +1,115 @@
+package newznab
+
+import "strconv"
+
+// Attributes holds the newznab and torznab attributes of an item, in the
+// order they appear.  Get, Values and Has match attributes by name in any
+// namespace, as an AttributeRegistry does for handlers registered for every
+// namespace; use InNamespace to tell apart attributes of the same name in
+// different namespaces.
+type Attributes []Attribute
+
+// Get returns the first value of the named attribute, or an empty string if
+// it is not present
+func (a Attributes) Get(name string) string {
+	for _, attr := range a {
+		if attr.Name == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// Values returns every value of the named attribute
+func (a Attributes) Values(name string) (values []string) {
+	for _, attr := range a {
+		if attr.Name == name {
+			values = append(values, attr.Value)
+		}
+	}
+	return values
+}
+
+// Has returns whether the named attribute is present
+func (a Attributes) Has(name string) bool {
+	for _, attr := range a {
+		if attr.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// InNamespace returns the attributes in the given namespace, e.g.
+// NamespaceTorznab
+func (a Attributes) InNamespace(namespace string) (attrs Attributes) {
+	for _, attr := range a {
+		if attr.Namespace == namespace {
+			attrs = append(attrs, attr)
+		}
+	}
+	return attrs
+}
+
+// Item holds the standard RSS fields of the item an Entry was parsed from,
+// exactly as given by the indexer
+type Item struct {
+	// title of the item
+	Title string
+	// link element; usually a download or details URL
+	Link string
+	// URL of the page holding comments on the item
+	Comments string
+	// author of the item
+	Author string
+	// description of the item
+	Description string
+	// text of the guid element
+	GUID string
+	// whether the guid element is a permanent link to the item
+	GUIDIsPermaLink bool
+	// category elements, in the order they appear
+	Category []ItemCategory
+	// text of the pubDate element
+	PubDate string
+	// source element
+	Source ItemSource
+	// enclosure element
+	Enclosure ItemEnclosure
+}
+
+// ItemCategory describes a category element of an RSS item
+type ItemCategory struct {
+	// text of the category element, e.g. "TV > HD"
+	Name string
+	// domain attribute of the category element
+	Domain string
+}
+
+// ItemSource describes the source element of an RSS item
+type ItemSource struct {
+	// URL of the feed the item came from
+	URL string
+	// title of the feed the item came from
+	Title string
+}
+
+// ItemEnclosure describes the enclosure element of an RSS item
+type ItemEnclosure struct {
+	// URL of the enclosed file
+	URL string
+	// length of the enclosed file in bytes, as given by the indexer
+	Length string
+	// MIME type of the enclosed file
+	Type string
+}
+
+// Size returns the length of the enclosed file in bytes, or 0 if the indexer
+// did not give a valid length
+func (e ItemEnclosure) Size() uint64 {
+	size, err := strconv.ParseUint(e.Length, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return size
+}
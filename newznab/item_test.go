@@ -0,0 +1,68 @@
+package newznab
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestSearchPreservesAttributesAndItem(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+
+	result, err := client.Do(context.Background(), SearchRequest{
+		Function:   FunctionSearch,
+		Query:      "Extensions",
+		Categories: []Category{CategoryTVAll},
+	})
+	if err != nil {
+		t.Fatalf("Failed to search mock indexer; %v", err)
+	}
+	if len(result.Entries) != 1 {
+		t.Fatalf("Wrong number of results; got %d expected %d", len(result.Entries), 1)
+	}
+	entry := result.Entries[0]
+
+	if !reflect.DeepEqual(entry.Attributes.Values("category"), []string{"5000", "5040"}) {
+		t.Errorf("Repeated attributes should be kept in order; got %v", entry.Attributes.Values("category"))
+	}
+	if entry.Attributes.Get("x-indexer-score") != "87" || entry.Attributes.Get("group") != "alt.binaries.teevee" {
+		t.Errorf("Unknown attributes should be preserved; got %v", entry.Attributes)
+	}
+	if !reflect.DeepEqual(entry.Attributes.Values("x-indexer-score"), []string{"87", "91"}) {
+		t.Errorf("Attributes of the same name should be kept from every namespace; got %v", entry.Attributes.Values("x-indexer-score"))
+	}
+	if torznab := entry.Attributes.InNamespace(NamespaceTorznab); len(torznab) != 1 || torznab.Get("x-indexer-score") != "91" {
+		t.Errorf("Wrong torznab attributes; got %v", torznab)
+	}
+	if !entry.Attributes.Has("nuked") || entry.Attributes.Has("seeders") || entry.Attributes.Get("seeders") != "" {
+		t.Errorf("Wrong attribute presence; got %v", entry.Attributes)
+	}
+
+	expected := Item{
+		Title:           "Extensions.S02E03.1080p.WEB.h264",
+		Link:            "http://nzb.example/getnzb/4f8e2a6c0b1d3e5f7a9c2b4d6e8f0a1c.nzb",
+		Comments:        "http://nzb.example/details/4f8e2a6c0b1d3e5f7a9c2b4d6e8f0a1c#comments",
+		Author:          "uploader@example.com (Uploader)",
+		Description:     "Extensions.S02E03.1080p.WEB.h264",
+		GUID:            "http://nzb.example/details/4f8e2a6c0b1d3e5f7a9c2b4d6e8f0a1c",
+		GUIDIsPermaLink: true,
+		Category: []ItemCategory{
+			{Name: "TV > HD", Domain: "http://nzb.example/cats"},
+			{Name: "Extensions", Domain: "http://nzb.example/tags"},
+		},
+		PubDate: "Wed, 08 Nov 2017 19:30:00 +0000",
+		Source:  ItemSource{URL: "http://nzb.example/rss", Title: "NZB Example"},
+		Enclosure: ItemEnclosure{
+			URL:    "http://nzb.example/getnzb/4f8e2a6c0b1d3e5f7a9c2b4d6e8f0a1c.nzb",
+			Length: "2684354560",
+			Type:   "application/x-nzb",
+		},
+	}
+	if !reflect.DeepEqual(entry.Item, expected) {
+		t.Errorf("Wrong item; got %+v expected %+v", entry.Item, expected)
+	}
+	if entry.Item.Enclosure.Size() != 2684354560 {
+		t.Errorf("Wrong enclosure size; got %d", entry.Item.Enclosure.Size())
+	}
+}
@@ -0,0 +1,209 @@
+package newznab
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/smquartz/errors"
+)
+
+// Magnet URN prefixes for the exact topic (xt) parameter
+const (
+	magnetBTIH = "urn:btih:"
+	magnetBTMH = "urn:btmh:"
+)
+
+// multihashSHA256 is the multihash prefix identifying a 32 byte SHA256 digest,
+// as used by v2 infohashes in btmh URNs
+const multihashSHA256 = "\x12\x20"
+
+// Magnet describes the contents of a BitTorrent magnet URI
+type Magnet struct {
+	// v1 infohash; the SHA1 hash of the info dictionary
+	InfoHash []byte
+	// v2 infohash; the SHA256 hash of the info dictionary, as per BEP 52
+	InfoHashV2 []byte
+	// display name of the torrent
+	DisplayName string
+	// tracker URLs
+	Trackers []string
+	// length of the torrent contents in bytes; zero if not given
+	ExactLength int64
+}
+
+// IsMagnetURL returns whether the given URL is a magnet URI
+func IsMagnetURL(u *url.URL) bool {
+	return u != nil && strings.EqualFold(u.Scheme, "magnet")
+}
+
+// ParseMagnet parses a magnet URI.  At least one v1 (btih, hex or base32
+// encoded) or v2 (btmh) infohash must be present.
+func ParseMagnet(s string) (*Magnet, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error parsing magnet URI", 1)
+	}
+	if !IsMagnetURL(u) {
+		return nil, errors.Errorf("not a magnet URI: %v", s)
+	}
+	values, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error parsing magnet URI parameters", 1)
+	}
+
+	m := new(Magnet)
+	for key, vals := range values {
+		switch {
+		case key == "xt" || strings.HasPrefix(key, "xt."):
+			for _, xt := range vals {
+				if err = m.parseExactTopic(xt); err != nil {
+					return nil, err
+				}
+			}
+		case key == "tr" || strings.HasPrefix(key, "tr."):
+			m.Trackers = append(m.Trackers, vals...)
+		case key == "dn":
+			m.DisplayName = vals[0]
+		case key == "xl":
+			m.ExactLength, err = strconv.ParseInt(vals[0], 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error parsing magnet exact length: %v", 1, vals[0])
+			}
+		}
+	}
+	if m.InfoHash == nil && m.InfoHashV2 == nil {
+		return nil, errors.Errorf("magnet URI has no infohash: %v", s)
+	}
+	return m, nil
+}
+
+// parseExactTopic parses a single xt parameter of a magnet URI; URNs other
+// than btih and btmh are ignored
+func (m *Magnet) parseExactTopic(xt string) error {
+	switch {
+	case strings.HasPrefix(strings.ToLower(xt), magnetBTIH):
+		hash := xt[len(magnetBTIH):]
+		switch len(hash) {
+		case hex.EncodedLen(sha1.Size):
+			decoded, err := hex.DecodeString(hash)
+			if err != nil {
+				return errors.Wrapf(err, "error decoding hex infohash: %v", 1, hash)
+			}
+			m.InfoHash = decoded
+		case base32.StdEncoding.EncodedLen(sha1.Size):
+			decoded, err := base32.StdEncoding.DecodeString(strings.ToUpper(hash))
+			if err != nil {
+				return errors.Wrapf(err, "error decoding base32 infohash: %v", 1, hash)
+			}
+			m.InfoHash = decoded
+		default:
+			return errors.Errorf("invalid btih infohash length: %v", hash)
+		}
+	case strings.HasPrefix(strings.ToLower(xt), magnetBTMH):
+		decoded, err := hex.DecodeString(xt[len(magnetBTMH):])
+		if err != nil {
+			return errors.Wrapf(err, "error decoding btmh multihash: %v", 1, xt)
+		}
+		if len(decoded) != len(multihashSHA256)+sha256.Size || string(decoded[:len(multihashSHA256)]) != multihashSHA256 {
+			return errors.Errorf("unsupported btmh multihash: %v", xt)
+		}
+		m.InfoHashV2 = decoded[len(multihashSHA256):]
+	}
+	return nil
+}
+
+// URL returns the magnet URI describing m
+func (m Magnet) URL() *url.URL {
+	// xt values are written unescaped as their colons are conventionally
+	// left as is, and hashes never need escaping
+	var params []string
+	if m.InfoHash != nil {
+		params = append(params, "xt="+magnetBTIH+hex.EncodeToString(m.InfoHash))
+	}
+	if m.InfoHashV2 != nil {
+		params = append(params, "xt="+magnetBTMH+hex.EncodeToString([]byte(multihashSHA256))+hex.EncodeToString(m.InfoHashV2))
+	}
+	if m.DisplayName != "" {
+		params = append(params, "dn="+url.QueryEscape(m.DisplayName))
+	}
+	if m.ExactLength > 0 {
+		params = append(params, "xl="+strconv.FormatInt(m.ExactLength, 10))
+	}
+	for _, tr := range m.Trackers {
+		params = append(params, "tr="+url.QueryEscape(tr))
+	}
+	return &url.URL{Scheme: "magnet", Opaque: "?" + strings.Join(params, "&")}
+}
+
+// String returns the magnet URI describing m
+func (m Magnet) String() string { return m.URL().String() }
+
+// Magnet returns a magnet URI for the torrent, built from its infohash, name
+// and trackers.  If no infohash is known, the magnet URI advertised by the
+// indexer is returned, which may be nil.
+func (t TorrentFile) Magnet() *url.URL {
+	if t.InfoHash == nil && t.InfoHashV2 == nil {
+		return t.MagnetURL
+	}
+	m := Magnet{
+		InfoHash:    t.InfoHash,
+		InfoHashV2:  t.InfoHashV2,
+		DisplayName: t.Name,
+		ExactLength: int64(t.ContentsSize),
+		Trackers:    t.Trackers,
+	}
+	if t.Metainfo != nil {
+		if m.DisplayName == "" {
+			m.DisplayName = t.Metainfo.Name
+		}
+		m.Trackers = appendTrackers(m.Trackers, t.Metainfo.Announce)
+		for _, tier := range t.Metainfo.AnnounceList {
+			m.Trackers = appendTrackers(m.Trackers, tier...)
+		}
+	}
+	return m.URL()
+}
+
+// applyMagnet fills in the fields of the TorrentFile not already set from the
+// given magnet URI
+func (t *TorrentFile) applyMagnet(m *Magnet) {
+	if t.InfoHash == nil {
+		t.InfoHash = m.InfoHash
+	}
+	if t.InfoHashV2 == nil {
+		t.InfoHashV2 = m.InfoHashV2
+	}
+	if t.Name == "" {
+		t.Name = m.DisplayName
+	}
+	if t.ContentsSize == 0 && m.ExactLength > 0 {
+		t.ContentsSize = uint64(m.ExactLength)
+	}
+	t.Trackers = appendTrackers(t.Trackers, m.Trackers...)
+}
+
+// appendTrackers appends the given trackers to list, skipping empty and
+// duplicate URLs
+func appendTrackers(list []string, trackers ...string) []string {
+	for _, tr := range trackers {
+		if tr == "" {
+			continue
+		}
+		duplicate := false
+		for _, existing := range list {
+			if existing == tr {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			list = append(list, tr)
+		}
+	}
+	return list
+}
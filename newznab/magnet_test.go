@@ -0,0 +1,123 @@
+package newznab
+
+import (
+	"context"
+	"encoding/hex"
+	"reflect"
+	"testing"
+)
+
+const sintelInfoHash = "08ada5a7a6183aae1e09d831df6748d566095a10"
+
+func TestParseMagnet(t *testing.T) {
+	expected, _ := hex.DecodeString(sintelInfoHash)
+
+	m, err := ParseMagnet("magnet:?xt=urn:btih:" + sintelInfoHash + "&dn=Sintel&tr=udp%3A%2F%2Fexplodie.org%3A6969&tr=wss%3A%2F%2Ftracker.example&xl=129241752")
+	if err != nil {
+		t.Fatalf("Failed to parse magnet URI; %v", err)
+	}
+	if !reflect.DeepEqual(m.InfoHash, expected) || m.DisplayName != "Sintel" || m.ExactLength != 129241752 {
+		t.Errorf("Wrong magnet fields; got %+v", *m)
+	}
+	if !reflect.DeepEqual(m.Trackers, []string{"udp://explodie.org:6969", "wss://tracker.example"}) {
+		t.Errorf("Wrong trackers; got %v", m.Trackers)
+	}
+
+	m, err = ParseMagnet("magnet:?xt=urn:btih:BCW2LJ5GDA5K4HQJ3AY56Z2I2VTASWQQ")
+	if err != nil {
+		t.Fatalf("Failed to parse base32 magnet URI; %v", err)
+	}
+	if !reflect.DeepEqual(m.InfoHash, expected) {
+		t.Errorf("Wrong base32 infohash; got %x", m.InfoHash)
+	}
+
+	v2 := "1220caf1e1c30e81cb361b9ee167c4aa64228a7fa4fa9f6105232b28ad099f3a302e"
+	m, err = ParseMagnet("magnet:?xt=urn:btih:" + sintelInfoHash + "&xt=urn:btmh:" + v2)
+	if err != nil {
+		t.Fatalf("Failed to parse hybrid magnet URI; %v", err)
+	}
+	if hex.EncodeToString(m.InfoHashV2) != v2[4:] || m.InfoHash == nil {
+		t.Errorf("Wrong hybrid infohashes; got %x, %x", m.InfoHash, m.InfoHashV2)
+	}
+	reparsed, err := ParseMagnet(m.String())
+	if err != nil || !reflect.DeepEqual(reparsed, m) {
+		t.Errorf("Round trip failed; got %+v, %v expected %+v", reparsed, err, m)
+	}
+
+	for _, invalid := range []string{
+		"http://example.com/?xt=urn:btih:" + sintelInfoHash,
+		"magnet:?dn=Sintel",
+		"magnet:?xt=urn:btih:1234",
+		"magnet:?xt=urn:btmh:1114" + sintelInfoHash,
+	} {
+		if _, err = ParseMagnet(invalid); err == nil {
+			t.Errorf("ParseMagnet(%q) should have errored", invalid)
+		}
+	}
+}
+
+func TestTorrentFileMagnet(t *testing.T) {
+	torrent := NewTorrentFile()
+	if torrent.Magnet() != nil {
+		t.Errorf("Magnet should be nil without an infohash")
+	}
+	torrent.InfoHash, _ = hex.DecodeString(sintelInfoHash)
+	torrent.Name = "Sintel 2010"
+	torrent.Trackers = []string{"udp://explodie.org:6969"}
+	expected := "magnet:?xt=urn:btih:" + sintelInfoHash + "&dn=Sintel+2010&tr=udp%3A%2F%2Fexplodie.org%3A6969"
+	if got := torrent.Magnet().String(); got != expected {
+		t.Errorf("Wrong magnet URI; got %v expected %v", got, expected)
+	}
+	if torrent.URL().String() != expected {
+		t.Errorf("URL should fall back to the magnet URI; got %v", torrent.URL())
+	}
+}
+
+func TestSearchMagnetEnclosure(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+
+	result, err := client.Do(context.Background(), SearchRequest{
+		Function:   FunctionSearch,
+		Query:      "Sintel",
+		Categories: []Category{CategoryTVAll},
+	})
+	if err != nil {
+		t.Fatalf("Failed to search mock indexer; %v", err)
+	}
+	if len(result.Entries) != 2 {
+		t.Fatalf("Wrong number of results; got %d expected %d", len(result.Entries), 2)
+	}
+	entry := result.Entries[0]
+	torrent, ok := entry.File.(*TorrentFile)
+	if !ok {
+		t.Fatalf("File should be *TorrentFile; got %T", entry.File)
+	}
+	if torrent.DownloadURL != nil || !IsMagnetURL(torrent.URL()) {
+		t.Errorf("Magnet enclosure should not be used as a download URL; got %v, %v", torrent.DownloadURL, torrent.URL())
+	}
+	if hex.EncodeToString(torrent.InfoHash) != sintelInfoHash || torrent.ContentsSize != 129241752 || torrent.Name != "Sintel" {
+		t.Errorf("Magnet fields not applied; got %x, %d, %v", torrent.InfoHash, torrent.ContentsSize, torrent.Name)
+	}
+	if err = entry.PopulateFileContext(context.Background(), client); err != nil {
+		t.Errorf("Populating a magnet only torrent should not fail; %v", err)
+	}
+
+	// malformed magnets are recorded as warnings rather than failing the
+	// search
+	malformed := result.Entries[1]
+	torrent, ok = malformed.File.(*TorrentFile)
+	if !ok {
+		t.Fatalf("File should be *TorrentFile; got %T", malformed.File)
+	}
+	if torrent.MagnetURL != nil || torrent.InfoHash != nil || torrent.Seeders != 12 {
+		t.Errorf("Malformed magnets should be skipped; got %v, %x, %d", torrent.MagnetURL, torrent.InfoHash, torrent.Seeders)
+	}
+	fields := map[string]bool{}
+	for _, warning := range malformed.Meta.Warnings {
+		fields[warning.Field] = true
+	}
+	if !fields["magneturl"] || !fields["enclosure"] {
+		t.Errorf("Malformed magnets should produce warnings; got %v", malformed.Meta.Warnings)
+	}
+}
@@ -0,0 +1,78 @@
+package newznab
+
+import (
+	"net/url"
+	"strings"
+)
+
+// MediaInfo describes the audio and video properties of a release, and any
+// other metadata returned by indexers when extended attributes are requested
+type MediaInfo struct {
+	// video codec, e.g. "x264"
+	Video string
+	// audio codec and channels, e.g. "AC3 5.1"
+	Audio string
+	// resolution of the video, e.g. "1920x1080"
+	Resolution string
+	// frames per second of the video
+	Framerate float64
+	// languages of the audio tracks
+	Languages []string
+	// languages of the subtitles
+	Subtitles []string
+	// year the content was released
+	Year int
+	// tagline of the content according to IMDB
+	Tagline string
+	// plot of the content according to IMDB
+	Plot string
+	// director of the content according to IMDB
+	Director string
+	// actors in the content according to IMDB
+	Actors []string
+	// URL of a backdrop image for the content
+	Backdrop *url.URL
+}
+
+// HasLanguage returns whether the release has an audio track in the given
+// language, compared case-insensitively
+func (m MediaInfo) HasLanguage(language string) bool {
+	return containsFold(m.Languages, language)
+}
+
+// HasSubtitles returns whether the release has subtitles in the given
+// language, compared case-insensitively.  If language is empty, it returns
+// whether the release has subtitles in any language.
+func (m MediaInfo) HasSubtitles(language string) bool {
+	if language == "" {
+		return len(m.Subtitles) > 0
+	}
+	return containsFold(m.Subtitles, language)
+}
+
+// containsFold returns whether list contains s, compared case-insensitively
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitList splits an attribute value listing several items, such as
+// "English - French" or "English, French", appending the items not already
+// in list
+func splitList(list []string, value string) []string {
+	value = strings.Replace(value, " - ", ",", -1)
+	items := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == '/' || r == '|'
+	})
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item != "" && !containsFold(list, item) {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,59 @@
+package newznab
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestSearchExtendedMediaInfo(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+	client.Extended = true
+
+	result, err := client.Do(context.Background(), SearchRequest{
+		Function:   FunctionMovieSearch,
+		IMDBID:     816692,
+		Categories: []Category{CategoryMovieAll},
+	})
+	if err != nil {
+		t.Fatalf("Failed to search mock indexer; %v", err)
+	}
+	if len(result.Entries) != 2 {
+		t.Fatalf("Wrong number of results; got %d expected %d", len(result.Entries), 2)
+	}
+
+	media := result.Entries[0].Media
+	if media.Video != "x264" || media.Audio != "DTS 5.1" || media.Resolution != "1920x1080" || media.Framerate != 23.976 {
+		t.Errorf("Wrong audio or video properties; got %+v", media)
+	}
+	if !reflect.DeepEqual(media.Languages, []string{"English", "French", "German"}) {
+		t.Errorf("Wrong languages; got %v", media.Languages)
+	}
+	if !media.HasLanguage("french") || media.HasLanguage("Spanish") {
+		t.Errorf("Wrong HasLanguage results for %v", media.Languages)
+	}
+	if !media.HasSubtitles("spanish") || !media.HasSubtitles("") || media.HasSubtitles("German") {
+		t.Errorf("Wrong HasSubtitles results for %v", media.Subtitles)
+	}
+	if media.Year != 2014 || media.Director != "Christopher Nolan" || media.Tagline == "" || media.Plot == "" {
+		t.Errorf("Wrong metadata; got %+v", media)
+	}
+	if !reflect.DeepEqual(media.Actors, []string{"Matthew McConaughey", "Anne Hathaway", "Jessica Chastain"}) {
+		t.Errorf("Wrong actors; got %v", media.Actors)
+	}
+	if media.Backdrop == nil || media.Backdrop.Path != "/covers/movies/0816692-backdrop.jpg" {
+		t.Errorf("Wrong backdrop; got %v", media.Backdrop)
+	}
+	if movie, ok := result.Entries[0].Content.(*Movie); !ok || movie.TMDBID != 157336 {
+		t.Errorf("Extended IDs should be attributed to the movie; got %+v", result.Entries[0].Content)
+	}
+
+	second := result.Entries[1]
+	if second.Media.Framerate != 0 || len(second.Meta.Warnings) != 1 || second.Meta.Warnings[0].Field != "framerate" {
+		t.Errorf("An invalid framerate should be recorded as a warning; got %v, %v", second.Media.Framerate, second.Meta.Warnings)
+	}
+	if second.Media.HasSubtitles("") {
+		t.Errorf("Entry without subs should not have subtitles")
+	}
+}
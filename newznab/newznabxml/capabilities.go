@@ -0,0 +1,66 @@
+package newznab
+
+// rawCapabilities describes the XML response format returned by the caps
+// function of the newznab API
+type rawCapabilities struct {
+	Server struct {
+		AppVersion string `xml:"appversion,attr"`
+		Version    string `xml:"version,attr"`
+		Title      string `xml:"title,attr"`
+		Strapline  string `xml:"strapline,attr"`
+		Email      string `xml:"email,attr"`
+		URL        string `xml:"url,attr"`
+		Image      string `xml:"image,attr"`
+	} `xml:"server"`
+
+	Limits struct {
+		Max     int `xml:"max,attr"`
+		Default int `xml:"default,attr"`
+		APIMax  int `xml:"apimax,attr"`
+		GrabMax int `xml:"grabmax,attr"`
+	} `xml:"limits"`
+
+	Registration struct {
+		Available string `xml:"available,attr"`
+		Open      string `xml:"open,attr"`
+	} `xml:"registration"`
+
+	Searching struct {
+		Search      rawSearchMode `xml:"search"`
+		TVSearch    rawSearchMode `xml:"tv-search"`
+		MovieSearch rawSearchMode `xml:"movie-search"`
+		AudioSearch rawSearchMode `xml:"audio-search"`
+		BookSearch  rawSearchMode `xml:"book-search"`
+	} `xml:"searching"`
+
+	Categories []rawCapabilityCategory `xml:"categories>category"`
+
+	Groups []struct {
+		ID          int    `xml:"id,attr"`
+		Name        string `xml:"name,attr"`
+		Description string `xml:"description,attr"`
+		LastUpdate  string `xml:"lastupdate,attr"`
+	} `xml:"groups>group"`
+
+	Genres []struct {
+		ID         int    `xml:"id,attr"`
+		CategoryID int    `xml:"categoryid,attr"`
+		Name       string `xml:"name,attr"`
+	} `xml:"genres>genre"`
+}
+
+// rawSearchMode describes a single search function advertised in the
+// searching section of a caps response
+type rawSearchMode struct {
+	Available       string `xml:"available,attr"`
+	SupportedParams string `xml:"supportedParams,attr"`
+}
+
+// rawCapabilityCategory describes a category, and its subcategories, as
+// advertised in a caps response
+type rawCapabilityCategory struct {
+	ID            int                     `xml:"id,attr"`
+	Name          string                  `xml:"name,attr"`
+	Description   string                  `xml:"description,attr"`
+	Subcategories []rawCapabilityCategory `xml:"subcat"`
+}
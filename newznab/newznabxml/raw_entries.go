@@ -9,7 +9,7 @@ type rawEntry struct {
 	Title    string `xml:"title,omitempty"`
 	Link     string `xml:"link,omitempty"`
 	Size     int64  `xml:"size,omitempty"`
-	Category struct {
+	Category []struct {
 		Domain string `xml:"domain,attr"`
 		Value  string `xml:",chardata"`
 	} `xml:"category,omitempty"`
@@ -25,7 +25,7 @@ type rawEntry struct {
 
 	Source struct {
 		URL   string `xml:"url,attr"`
-		Value string `xml:"url,chardata"`
+		Value string `xml:",chardata"`
 	} `xml:"source,omitempty"`
 
 	Date xmlTime `xml:"pubDate,omitempty"`
@@ -48,10 +48,8 @@ type rawAttribute struct {
 
 // rawEntries describes responses returned when searching for newznab entries
 type rawEntries struct {
-	Version   string `xml:"version,attr"`
-	ErrorCode int    `xml:"code,attr"`
-	ErrorDesc string `xml:"description,attr"`
-	Channel   struct {
+	Version string `xml:"version,attr"`
+	Channel struct {
 		Title string `xml:"title"`
 		Link  struct {
 			Href string `xml:"href,attr"`
@@ -60,7 +58,7 @@ type rawEntries struct {
 		} `xml:"http://www.w3.org/2005/Atom link"`
 		Description string `xml:"description"`
 		Language    string `xml:"language,omitempty"`
-		Webmaster   string `xml:"webmaster,omitempty"`
+		Webmaster   string `xml:"webMaster,omitempty"`
 		Category    string `xml:"category,omitempty"`
 		Image       struct {
 			URL         string `xml:"url"`
@@ -76,6 +74,13 @@ type rawEntries struct {
 			Total  int `xml:"total,attr"`
 		} `xml:"http://www.newznab.com/DTD/2010/feeds/attributes/ response"`
 
+		APILimits struct {
+			APICurrent  int `xml:"apicurrent,attr"`
+			APIMax      int `xml:"apimax,attr"`
+			GrabCurrent int `xml:"grabcurrent,attr"`
+			GrabMax     int `xml:"grabmax,attr"`
+		} `xml:"http://www.newznab.com/DTD/2010/feeds/attributes/ apilimits"`
+
 		// All entries that match the search query, up to the response limit.
 		Entries []rawEntry `xml:"item"`
 	} `xml:"channel"`
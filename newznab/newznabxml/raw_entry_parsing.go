@@ -1,6 +1,7 @@
 package newznab
 
 import (
+	"context"
 	"encoding/hex"
 	"net/url"
 	"strconv"
@@ -8,85 +9,207 @@ import (
 	"time"
 
 	log "github.com/Sirupsen/logrus"
-	uuid "github.com/satori/go.uuid"
 	"github.com/smquartz/errors"
 )
 
-func rawEntriesToEntries(c *Client, raw rawEntries) (entries Entries, err error) {
+// rawEntriesToEntries converts the items within rawEntries into Entries.  Only
+// the enrichments requested in opts are performed, using the given context.
+func rawEntriesToEntries(ctx context.Context, c *Client, raw rawEntries, opts SearchOptions) (entries Entries, err error) {
 	for _, rawItem := range raw.Channel.Entries {
 		entry := new(Entry)
 		entry.General.Title = rawItem.Title
 		entry.General.Description = rawItem.Description
-		entry.Meta.Dates.Published = rawItem.Date.Add(0)
 		entry.Meta.Source.APIKey = c.APIKey
 		entry.Meta.Source.Endpoint = c.BaseURL
 
-		err = entry.fromRawEntry(rawItem)
+		err = entry.fromRawEntry(rawItem, c.DateParser, c.attributeRegistry())
 		if err != nil {
 			return nil, errors.Wrapf(err, "error parsing attributes", 1)
 		}
+		// torznab indexers frequently omit the guid attribute, leaving only
+		// the item's guid element
+		if entry.Meta.ID.IsZero() {
+			entry.Meta.ID = EntryID(strings.TrimSpace(rawItem.GUID.GUID))
+		}
 
 		if torrent, ok := entry.File.(*TorrentFile); ok {
 			u, err := url.Parse(rawItem.Enclosure.URL)
 			if err != nil {
-				return nil, errors.Wrapf(err, "error parsing enclosure URL: %v", 1, rawItem.Enclosure.URL)
+				entry.addWarning("enclosure", rawItem.Enclosure.URL, err)
+			} else if IsMagnetURL(u) {
+				// some indexers only offer magnet links, in which case the
+				// enclosure is not downloadable
+				if m, err := ParseMagnet(rawItem.Enclosure.URL); err != nil {
+					entry.addWarning("enclosure", rawItem.Enclosure.URL, err)
+				} else {
+					if torrent.MagnetURL == nil {
+						torrent.MagnetURL = u
+					}
+					torrent.applyMagnet(m)
+				}
+			} else {
+				torrent.DownloadURL = u
+			}
+			if torrent.Name == "" {
+				torrent.Name = entry.General.Title
 			}
-			torrent.DownloadURL = u
-		}
-
-		err = entry.PopulateComments(c)
-		if err != nil {
-			// return nil, errors.Wrapf(err, "error populating comments", 1)
-			log.Println(errors.Wrapf(err, "error populating comments", 1))
 		}
 
-		entry.PopulateFile(c)
-		/* if err != nil {
-			// return nil, errors.Wrapf(err, "error populating File", 1)
-			log.Println(errors.Wrapf(err, "error populating File", 1))
-		} */
-
 		entries = append(entries, *entry)
 	}
+
+	// enrichment failures are not fatal to the search itself
+	err = entries.Populate(ctx, c, PopulateOptions{
+		Enrichments: opts.Enrichments,
+		Concurrency: opts.Concurrency,
+	})
+	if err != nil {
+		log.Println(errors.Wrapf(err, "error enriching entries", 1))
+	}
 	return entries, nil
 }
 
 // fromRawEntry accepts a rawEntry and sets the called on Entry's
-// fields based on the values of rawEntry
-func (e *Entry) fromRawEntry(raw rawEntry) (err error) {
+// fields based on the values of rawEntry, using the handlers in registry for
+// its attributes
+func (e *Entry) fromRawEntry(raw rawEntry, dates *DateParser, registry *AttributeRegistry) (err error) {
+	e.Item = itemFromRaw(raw)
+	if raw.Date.Raw != "" {
+		if published, ok := e.parseDate(dates, "pubDate", raw.Date.Raw); ok {
+			e.Meta.Dates.Published = published
+		}
+	}
+	e.Attributes = make(Attributes, 0, len(raw.Attributes))
 	for _, attr := range raw.Attributes {
-		err = e.fromRawAttribute(attr)
+		e.Attributes = append(e.Attributes, Attribute{Namespace: attr.XMLName.Space, Name: attr.Name, Value: attr.Value, dates: dates})
+	}
+	for _, attr := range e.Attributes {
+		err = e.fromRawAttribute(attr, registry)
+		if err != nil {
+			return errors.Wrapf(err, "error proceesing attribute", 1)
+		}
+	}
+	// a size preceding the torrent specific attributes could not be
+	// attributed when it was encountered
+	if torrent, ok := e.File.(*TorrentFile); ok && torrent.ContentsSize == 0 && e.Attributes.Has("size") {
+		err = e.fromRawTorrentAttribute(Attribute{Name: "size", Value: e.Attributes.Get("size")})
 		if err != nil {
 			return errors.Wrapf(err, "error proceesing attribute", 1)
 		}
 	}
+	if music, ok := e.Content.(*Music); ok {
+		music.Genre = e.General.Categorisation.Genre
+	}
 	return nil
 }
 
-// fromRawAttribute accepts a raw XML attribute and sets the corresponding
-// field in Entry
-func (e *Entry) fromRawAttribute(raw rawAttribute) (err error) {
-	switch {
-	case strings.Contains("category,genre,info", raw.Name):
-		return e.fromRawGeneralAttribute(raw)
-	case strings.Contains("guid,comments,grabs,usenetdate", raw.Name):
-		return e.fromRawMetaAttribute(raw)
-	case strings.Contains("rating,tvtitle,episode,season,rageid,tvdbid,tvairdate,imdb,imdbtitle,imdbyear,imdbscore,coverurl", raw.Name):
-		return e.fromRawContentAttribute(raw)
-	case strings.Contains("size,seeders,peers,infohash", raw.Name):
-		return e.fromRawFileAttribute(raw)
-	default:
-		// return errors.Errorf("encountered unknown attribute %v: %v", raw.Name, raw.Value)
+// itemFromRaw copies the standard RSS fields of a rawEntry into an Item
+func itemFromRaw(raw rawEntry) Item {
+	var categories []ItemCategory
+	for _, category := range raw.Category {
+		categories = append(categories, ItemCategory{Name: category.Value, Domain: category.Domain})
 	}
-	return nil
+	return Item{
+		Title:           raw.Title,
+		Link:            raw.Link,
+		Comments:        raw.Comments,
+		Author:          raw.Author,
+		Description:     raw.Description,
+		GUID:            raw.GUID.GUID,
+		GUIDIsPermaLink: raw.GUID.IsPermaLink,
+		Category:        categories,
+		PubDate:         raw.Date.Raw,
+		Source: ItemSource{
+			URL:   raw.Source.URL,
+			Title: raw.Source.Value,
+		},
+		Enclosure: ItemEnclosure{
+			URL:    raw.Enclosure.URL,
+			Length: raw.Enclosure.Length,
+			Type:   raw.Enclosure.Type,
+		},
+	}
+}
+
+// inCategoryGroup returns whether any of the Entry's categories belong to
+// the group of categories headed by the given parent, e.g. CategoryAudioAll
+func (e *Entry) inCategoryGroup(parent Category) bool {
+	for _, c := range e.General.Categorisation.Category {
+		if c.Parent() == parent {
+			return true
+		}
+	}
+	return false
+}
+
+// inCategory returns whether the Entry belongs to the given category
+func (e *Entry) inCategory(category Category) bool {
+	for _, c := range e.General.Categorisation.Category {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// describesBook returns whether the Entry is known to describe a book,
+// either from its Content or its categories
+func (e *Entry) describesBook() bool {
+	if _, ok := e.Content.(*Book); ok {
+		return true
+	}
+	return e.Content == nil && (e.inCategoryGroup(CategoryBooksAll) || e.inCategory(CategoryAudioAudiobook))
+}
+
+// describesTV returns whether the Entry is known to describe a TV episode,
+// either from its Content or its categories
+func (e *Entry) describesTV() bool {
+	if _, ok := e.Content.(*TV); ok {
+		return true
+	}
+	return e.Content == nil && e.inCategoryGroup(CategoryTVAll)
+}
+
+// describesMovie returns whether the Entry is known to describe a movie,
+// either from its Content or its categories
+func (e *Entry) describesMovie() bool {
+	if _, ok := e.Content.(*Movie); ok {
+		return true
+	}
+	return e.Content == nil && e.inCategoryGroup(CategoryMovieAll)
+}
+
+// describesMusic returns whether the Entry is known to describe music,
+// either from its Content or its categories
+func (e *Entry) describesMusic() bool {
+	if _, ok := e.Content.(*Music); ok {
+		return true
+	}
+	return e.Content == nil && e.inCategoryGroup(CategoryAudioAll)
+}
+
+// fromRawAttribute accepts an attribute and passes it to the handler
+// registered for it, if any
+func (e *Entry) fromRawAttribute(attr Attribute, registry *AttributeRegistry) error {
+	handler, ok := registry.Handler(attr.Namespace, attr.Name)
+	if !ok {
+		return nil
+	}
+	return handler(e, attr)
 }
 
 // fromRawGeneralAttribute accepts a raw XML attribute that corresponds to a
-// field in Entry.General, and sets the corresponding field
-func (e *Entry) fromRawGeneralAttribute(raw rawAttribute) error {
+// field in Entry.General, and sets the corresponding field.  Categories that
+// cannot be parsed are skipped with a warning, but remain in Entry.Attributes.
+func (e *Entry) fromRawGeneralAttribute(raw Attribute) error {
 	switch raw.Name {
 	case "category":
-		e.General.Categorisation.Category = append(e.General.Categorisation.Category, raw.Value)
+		parsed, err := ParseCategory(raw.Value)
+		if err != nil {
+			e.addWarning(raw.Name, raw.Value, err)
+			return nil
+		}
+		e.General.Categorisation.Category = append(e.General.Categorisation.Category, parsed)
 	case "genre":
 		e.General.Categorisation.Genre = raw.Value
 	case "info":
@@ -99,13 +222,10 @@ func (e *Entry) fromRawGeneralAttribute(raw rawAttribute) error {
 
 // fromRawMetaAttribute accepts a raw XML attribute that corresponds to a
 // field in Entry.Meta, and sets the corresponding field
-func (e *Entry) fromRawMetaAttribute(raw rawAttribute) (err error) {
+func (e *Entry) fromRawMetaAttribute(raw Attribute) (err error) {
 	switch raw.Name {
 	case "guid":
-		e.Meta.ID, err = uuid.FromString(raw.Value)
-		if err != nil {
-			return errors.Wrapf(err, "error parsing rawEntry's ID: %s", 1, raw.Value)
-		}
+		e.Meta.ID = EntryID(raw.Value)
 	case "grabs":
 		parsedUint, err := strconv.ParseUint(raw.Value, 10, 64)
 		if err != nil {
@@ -119,10 +239,8 @@ func (e *Entry) fromRawMetaAttribute(raw rawAttribute) (err error) {
 		}
 		e.Meta.Comments.Number = parsedUint
 	case "usenetdate":
-		if parsedUsetnetDate, err := parseDate(raw.Value); err != nil {
-			return errors.Wrapf(err, "failed to parse usenet date: %v", 1, raw.Value)
-		} else {
-			e.Meta.Dates.Usenet = parsedUsetnetDate
+		if parsedUsenetDate, ok := e.parseDate(raw.dates, raw.Name, raw.Value); ok {
+			e.Meta.Dates.Usenet = parsedUsenetDate
 		}
 	default:
 		return errors.Errorf("encountered unknown attribute %v: %v", raw.Name, raw.Value)
@@ -132,25 +250,115 @@ func (e *Entry) fromRawMetaAttribute(raw rawAttribute) (err error) {
 
 // fromRawContentAttribute accepts a raw XML attribute that corresponds to a
 // field in Entry.Content, and sets the corresponding field
-func (e *Entry) fromRawContentAttribute(raw rawAttribute) error {
-	switch {
-	case strings.Contains("rating,tvtitle,episode,season,rageid,tvdbid,tvairdate", raw.Name):
+func (e *Entry) fromRawContentAttribute(raw Attribute) error {
+	switch raw.Name {
+	case "publisher":
+		// publisher is shared between books and music, so is attributed
+		// according to what the entry is known to describe
+		if e.describesBook() {
+			return e.fromRawBookAttribute(raw)
+		} else if e.describesMusic() {
+			return e.fromRawMusicAttribute(raw)
+		}
+		return nil
+	case "year":
+		if year, err := strconv.Atoi(raw.Value); err == nil {
+			e.Media.Year = year
+		}
+		// year is shared between content types, so is only attributed to
+		// music if the entry is known to describe music
+		if e.describesMusic() {
+			return e.fromRawMusicAttribute(raw)
+		}
+		return nil
+	case "booktitle", "author", "publishdate", "pages", "isbn":
+		return e.fromRawBookAttribute(raw)
+	case "tvdbid", "rageid", "tvmazeid", "anidbid":
+		return e.fromRawTVAttribute(raw)
+	case "imdb", "imdbid":
+		// IMDb IDs are given for series as well as movies, but have always
+		// been taken to describe a movie unless known otherwise
+		if e.describesTV() {
+			return e.fromRawTVAttribute(raw)
+		}
+		return e.fromRawMovieAttribute(raw)
+	case "tmdbid", "traktid", "doubanid":
+		// these IDs are used for both series and movies, so are attributed
+		// according to what the entry is known to describe
+		if e.describesTV() {
+			return e.fromRawTVAttribute(raw)
+		} else if e.describesMovie() {
+			return e.fromRawMovieAttribute(raw)
+		}
+		return nil
+	case "artist", "album", "label", "track":
+		return e.fromRawMusicAttribute(raw)
+	case "rating", "tvtitle", "episode", "season", "tvairdate":
 		return e.fromRawTVAttribute(raw)
-	case strings.Contains("imdb,imdbtitle,imdbyear,imdbscore,coverurl", raw.Name):
+	case "imdbtitle", "imdbyear", "imdbscore", "coverurl":
 		return e.fromRawMovieAttribute(raw)
 	default:
 		return errors.Errorf("encountered unknown attribute %v: %v", raw.Name, raw.Value)
 	}
 }
 
+// fromRawMediaAttribute accepts a raw XML attribute that corresponds to a
+// field in Entry.Media, and sets the corresponding field.  Values that cannot
+// be parsed are recorded as warnings, as they are not essential to the Entry.
+func (e *Entry) fromRawMediaAttribute(raw Attribute) error {
+	switch raw.Name {
+	case "video":
+		e.Media.Video = raw.Value
+	case "audio":
+		e.Media.Audio = raw.Value
+	case "resolution":
+		e.Media.Resolution = raw.Value
+	case "framerate":
+		value := strings.TrimSpace(strings.TrimSuffix(strings.ToLower(raw.Value), "fps"))
+		parsedFloat, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			e.addWarning(raw.Name, raw.Value, err)
+			return nil
+		}
+		e.Media.Framerate = parsedFloat
+	case "language":
+		e.Media.Languages = splitList(e.Media.Languages, raw.Value)
+	case "subs":
+		e.Media.Subtitles = splitList(e.Media.Subtitles, raw.Value)
+	case "imdbtagline":
+		e.Media.Tagline = raw.Value
+	case "imdbplot":
+		e.Media.Plot = raw.Value
+	case "imdbdirector":
+		e.Media.Director = raw.Value
+	case "imdbactors":
+		for _, actor := range strings.Split(raw.Value, ",") {
+			if actor = strings.TrimSpace(actor); actor != "" {
+				e.Media.Actors = append(e.Media.Actors, actor)
+			}
+		}
+	case "backdropcoverurl":
+		u, err := url.Parse(raw.Value)
+		if err != nil {
+			e.addWarning(raw.Name, raw.Value, err)
+			return nil
+		}
+		e.Media.Backdrop = u
+	default:
+		return errors.Errorf("encountered unknown attribute %v: %v", raw.Name, raw.Value)
+	}
+	return nil
+}
+
 // fromRawTVAttribute accepts a raw XML attribute that corresponds to a field
 // in the TV implementation of Entry.Content, and sets the corresponding field.
 // If Content is not already set, it will be set to TV.  If it is set to
-// another implementation, an error will be returned.
-func (e *Entry) fromRawTVAttribute(raw rawAttribute) error {
+// another implementation, the attribute is ignored and a warning recorded.
+func (e *Entry) fromRawTVAttribute(raw Attribute) error {
 	tv, ok := e.Content.(*TV)
 	if !ok && e.Content != nil {
-		return errors.Errorf("encountered TV specific attribute but Content implementation is not set to TV")
+		e.addWarning(raw.Name, raw.Value, errors.Errorf("encountered TV specific attribute but Content implementation is set to %T", e.Content))
+		return nil
 	} else if !ok {
 		e.Content = new(TV)
 		tv = e.Content.(*TV)
@@ -158,46 +366,51 @@ func (e *Entry) fromRawTVAttribute(raw rawAttribute) error {
 
 	switch raw.Name {
 	case "tvairdate":
-		if parsedAirDate, err := parseDate(raw.Value); err != nil {
-			return errors.Errorf("newznab:Client:Search: failed to parse tvairdate: %v", err)
-		} else {
+		if parsedAirDate, ok := e.parseDate(raw.dates, raw.Name, raw.Value); ok {
 			e.Content.SetAired(parsedAirDate)
 		}
-	case "tvdbid":
-		parsedInt, err := strconv.ParseInt(raw.Value, 10, 64)
-		if err != nil {
-			return errors.Wrapf(err, "error parsing TVDB ID: %v", 1, raw.Value)
+	case "tvdbid", "rageid", "tvmazeid", "anidbid", "imdb", "imdbid", "tmdbid", "traktid", "doubanid":
+		if err := tv.ExternalIDs.set(raw.Name, raw.Value); err != nil {
+			e.addWarning(raw.Name, raw.Value, err)
 		}
-		tv.TVDBID = parsedInt
-	case "rageid":
-		parsedInt, err := strconv.ParseInt(raw.Value, 10, 64)
-		if err != nil {
-			return errors.Wrapf(err, "error parsing TVRage ID: %v", 1, raw.Value)
+	case "season", "episode":
+		// the meaning of each depends on the other, e.g. an episode of 05/17
+		// is a date if the season is a year
+		if raw.Name == "season" {
+			tv.RawSeason = raw.Value
+		} else {
+			tv.RawEpisode = raw.Value
 		}
-		tv.TVRageID = parsedInt
-	case "season":
-		raw.Value = strings.Trim(strings.ToUpper(raw.Value), "S")
-		parsedUint, err := strconv.ParseUint(raw.Value, 10, 64)
-		if err != nil {
-			return errors.Wrapf(err, "error parsing season number: %v", 1, raw.Value)
+		season, episode := tv.RawSeason, tv.RawEpisode
+		if season == "" {
+			season = e.Attributes.Get("season")
 		}
-		tv.Season = uint(parsedUint)
-	case "episode":
-		raw.Value = strings.Trim(strings.ToUpper(raw.Value), "E")
-		if strings.Contains(raw.Value, "/") {
-			raw.Value = strings.Split(raw.Value, "/")[1]
+		if episode == "" {
+			episode = e.Attributes.Get("episode")
 		}
-		parsedUint, err := strconv.ParseUint(raw.Value, 10, 64)
+		spec, err := ParseEpisodeSpec(season, episode)
 		if err != nil {
-			return errors.Wrapf(err, "error parsing episode number: %v", 1, raw.Value)
+			// values such as ep=special are kept only in their raw form;
+			// the warning is recorded against whichever value is at fault
+			seasonOnly, seasonErr := ParseEpisodeSpec(season, "")
+			if (raw.Name == "season") == (seasonErr != nil) {
+				e.addWarning(raw.Name, raw.Value, err)
+			}
+			tv.EpisodeSpec = EpisodeSpec{}
+			tv.Season = seasonOnly.Season
+			tv.Episode = 0
+			return nil
 		}
-		tv.Episode = uint(parsedUint)
+		tv.EpisodeSpec = spec
+		tv.Season = spec.Season
+		tv.Episode = spec.Episode
 	case "tvtitle":
 		tv.CanonicalTitle = raw.Value
 	case "rating":
 		parsedFloat, err := strconv.ParseFloat(raw.Value, 64)
 		if err != nil {
-			return errors.Wrapf(err, "error parsing rating: %v", 1, raw.Value)
+			e.addWarning(raw.Name, raw.Value, err)
+			return nil
 		}
 		tv.Rating = parsedFloat
 	default:
@@ -209,23 +422,22 @@ func (e *Entry) fromRawTVAttribute(raw rawAttribute) error {
 // fromRawMovieAttribute accepts a raw XML attribute that corresponds to a field
 // in the Movie implementation of Entry.Content, and sets the corresponding field.
 // If Content is not already set, it will be set to Movie.  If it is set to
-// another implementation, an error will be returned.
-func (e *Entry) fromRawMovieAttribute(raw rawAttribute) error {
+// another implementation, the attribute is ignored and a warning recorded.
+func (e *Entry) fromRawMovieAttribute(raw Attribute) error {
 	movie, ok := e.Content.(*Movie)
 	if !ok && e.Content != nil {
-		return errors.Errorf("encountered Movie specific attribute but Content implementation is not set to Movie")
+		e.addWarning(raw.Name, raw.Value, errors.Errorf("encountered Movie specific attribute but Content implementation is set to %T", e.Content))
+		return nil
 	} else if !ok {
 		e.Content = new(Movie)
 		movie = e.Content.(*Movie)
 	}
 
 	switch raw.Name {
-	case "imdb":
-		parsedInt, err := strconv.ParseInt(raw.Value, 10, 64)
-		if err != nil {
-			return errors.Wrapf(err, "error parsing IMDB ID: %v", 1, raw.Value)
+	case "imdb", "imdbid", "tmdbid", "traktid", "doubanid":
+		if err := movie.ExternalIDs.set(raw.Name, raw.Value); err != nil {
+			e.addWarning(raw.Name, raw.Value, err)
 		}
-		movie.IMDBID = parsedInt
 	case "imdbtitle":
 		movie.IMDBTitle = raw.Value
 	case "imdbyear":
@@ -253,29 +465,108 @@ func (e *Entry) fromRawMovieAttribute(raw rawAttribute) error {
 	return nil
 }
 
-// fromRawFileAttribute accepts a raw XML attribute that corresponds to a
-// field in Entry.File, and sets the corresponding field
-func (e *Entry) fromRawFileAttribute(raw rawAttribute) error {
-	switch {
-	case strings.Contains("size,seeders,peers,infohash", raw.Name):
-		return e.fromRawTorrentAttribute(raw)
-	/* case strings.Contains("", raw.Name):
-	return e.fromRawNZBAttribute(raw) */
+// fromRawMusicAttribute accepts a raw XML attribute that corresponds to a field
+// in the Music implementation of Entry.Content, and sets the corresponding field.
+// If Content is not already set, it will be set to Music.  If it is set to
+// another implementation, the attribute is ignored and a warning recorded.
+func (e *Entry) fromRawMusicAttribute(raw Attribute) error {
+	music, ok := e.Content.(*Music)
+	if !ok && e.Content != nil {
+		e.addWarning(raw.Name, raw.Value, errors.Errorf("encountered Music specific attribute but Content implementation is set to %T", e.Content))
+		return nil
+	} else if !ok {
+		e.Content = new(Music)
+		music = e.Content.(*Music)
+	}
+
+	switch raw.Name {
+	case "artist":
+		music.Artist = raw.Value
+	case "album":
+		music.Album = raw.Value
+	case "label":
+		music.Label = raw.Value
+	case "publisher":
+		music.Publisher = raw.Value
+	case "track":
+		music.Track = raw.Value
+	case "year":
+		parsedUint, err := strconv.ParseUint(raw.Value, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "error parsing music year: %v", 1, raw.Value)
+		}
+		music.Year = int(parsedUint)
+		if music.ReleaseDate.IsZero() {
+			music.ReleaseDate = time.Date(music.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		}
+	default:
+		return errors.Errorf("encountered unknown attribute %v: %v", raw.Name, raw.Value)
+	}
+	return nil
+}
+
+// fromRawBookAttribute accepts a raw XML attribute that corresponds to a field
+// in the Book implementation of Entry.Content, and sets the corresponding field.
+// If Content is not already set, it will be set to Book.  If it is set to
+// another implementation, the attribute is ignored and a warning recorded.
+func (e *Entry) fromRawBookAttribute(raw Attribute) error {
+	book, ok := e.Content.(*Book)
+	if !ok && e.Content != nil {
+		e.addWarning(raw.Name, raw.Value, errors.Errorf("encountered Book specific attribute but Content implementation is set to %T", e.Content))
+		return nil
+	} else if !ok {
+		e.Content = new(Book)
+		book = e.Content.(*Book)
+	}
+
+	switch raw.Name {
+	case "booktitle":
+		book.BookTitle = raw.Value
+	case "author":
+		book.Author = raw.Value
+	case "publisher":
+		book.Publisher = raw.Value
+	case "publishdate":
+		if parsedDate, ok := e.parseDate(raw.dates, raw.Name, raw.Value); ok {
+			book.PublishDate = parsedDate
+		}
+	case "pages":
+		parsedUint, err := strconv.ParseUint(raw.Value, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "error parsing number of pages: %v", 1, raw.Value)
+		}
+		book.Pages = int(parsedUint)
+	case "isbn":
+		book.ISBN = strings.Replace(raw.Value, "-", "", -1)
 	default:
 		return errors.Errorf("encountered unknown attribute %v: %v", raw.Name, raw.Value)
 	}
+	return nil
+}
+
+// fromRawSizeAttribute accepts a size attribute from outside the torznab
+// namespace.  It is only attributed to the torrent if the Entry is already
+// known to describe one; otherwise it is left in Entry.Attributes, and
+// applied once the rest of the attributes are known.
+func (e *Entry) fromRawSizeAttribute(raw Attribute) error {
+	if _, ok := e.File.(*TorrentFile); ok {
+		return e.fromRawTorrentAttribute(raw)
+	}
+	return nil
 }
 
 // fromRawTorrentAttribute accepts a raw XML attribute that corresponds to a field
 // in the TorrentFile implementation of Entry.File, and sets the corresponding field.
 // If File is not already set, it will be set to TorrentFile.  If it is set to
-// another implementation, an error will be returned.
-func (e *Entry) fromRawTorrentAttribute(raw rawAttribute) error {
+// another implementation, the attribute is ignored and a warning recorded, as
+// are malformed values.
+func (e *Entry) fromRawTorrentAttribute(raw Attribute) error {
 	torrent, ok := e.File.(*TorrentFile)
 	if !ok && e.File != nil {
-		return errors.Errorf("encountered Torrent specific attribute but File implementation is not set to Torrent")
+		e.addWarning(raw.Name, raw.Value, errors.Errorf("encountered Torrent specific attribute but File implementation is set to %T", e.File))
+		return nil
 	} else if !ok {
-		e.File = new(TorrentFile)
+		e.File = NewTorrentFile()
 		torrent = e.File.(*TorrentFile)
 	}
 
@@ -283,25 +574,86 @@ func (e *Entry) fromRawTorrentAttribute(raw rawAttribute) error {
 	case "size":
 		parsedUint, err := strconv.ParseUint(raw.Value, 10, 64)
 		if err != nil {
-			return errors.Wrapf(err, "error parsing torrent contents size: %v", 1, raw.Value)
+			e.addWarning(raw.Name, raw.Value, err)
+			return nil
 		}
 		torrent.ContentsSize = parsedUint
 	case "seeders":
 		parsedUint, err := strconv.ParseUint(raw.Value, 10, 64)
 		if err != nil {
-			return errors.Wrapf(err, "error parsing number of seeders: %v", 1, raw.Value)
+			e.addWarning(raw.Name, raw.Value, err)
+			return nil
 		}
 		torrent.Seeders = parsedUint
 	case "peers":
 		parsedUint, err := strconv.ParseUint(raw.Value, 10, 64)
 		if err != nil {
-			return errors.Wrapf(err, "error parsing number of peers: %v", 1, raw.Value)
+			e.addWarning(raw.Name, raw.Value, err)
+			return nil
 		}
 		torrent.Peers = parsedUint
+	case "leechers":
+		parsedUint, err := strconv.ParseUint(raw.Value, 10, 64)
+		if err != nil {
+			e.addWarning(raw.Name, raw.Value, err)
+			return nil
+		}
+		torrent.Leechers = parsedUint
+	case "files":
+		parsedUint, err := strconv.ParseUint(raw.Value, 10, 64)
+		if err != nil {
+			e.addWarning(raw.Name, raw.Value, err)
+			return nil
+		}
+		torrent.Files = parsedUint
+	case "magneturl":
+		u, err := url.Parse(raw.Value)
+		if err != nil {
+			e.addWarning(raw.Name, raw.Value, err)
+			return nil
+		}
+		m, err := ParseMagnet(raw.Value)
+		if err != nil {
+			e.addWarning(raw.Name, raw.Value, err)
+			return nil
+		}
+		torrent.MagnetURL = u
+		torrent.applyMagnet(m)
+	case "downloadvolumefactor":
+		parsedFloat, err := strconv.ParseFloat(raw.Value, 64)
+		if err != nil {
+			e.addWarning(raw.Name, raw.Value, err)
+			return nil
+		}
+		torrent.DownloadVolumeFactor = parsedFloat
+	case "uploadvolumefactor":
+		parsedFloat, err := strconv.ParseFloat(raw.Value, 64)
+		if err != nil {
+			e.addWarning(raw.Name, raw.Value, err)
+			return nil
+		}
+		torrent.UploadVolumeFactor = parsedFloat
+	case "minimumratio":
+		parsedFloat, err := strconv.ParseFloat(raw.Value, 64)
+		if err != nil {
+			e.addWarning(raw.Name, raw.Value, err)
+			return nil
+		}
+		torrent.MinimumRatio = parsedFloat
+	case "minimumseedtime":
+		parsedUint, err := strconv.ParseUint(raw.Value, 10, 64)
+		if err != nil {
+			e.addWarning(raw.Name, raw.Value, err)
+			return nil
+		}
+		torrent.MinimumSeedTime = time.Duration(parsedUint) * time.Second
+	case "tag":
+		torrent.Tags = append(torrent.Tags, raw.Value)
 	case "infohash":
 		parsedHex, err := hex.DecodeString(raw.Value)
 		if err != nil {
-			return errors.Wrapf(err, "error parsing infohash: %v", 1, raw.Value)
+			e.addWarning(raw.Name, raw.Value, err)
+			return nil
 		}
 		torrent.InfoHash = parsedHex
 	default:
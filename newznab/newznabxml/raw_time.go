@@ -4,21 +4,26 @@ import (
 	"encoding/xml"
 	"strings"
 	"time"
-
-	"github.com/smquartz/errors"
 )
 
+// xmlTime is a date element of a feed.  The text of the element is kept so
+// that it may be parsed according to the Client's DateParser; Time holds the
+// result of parsing it with the default DateParser, and is zero if that
+// failed.
 type xmlTime struct {
 	time.Time
+	Raw string
 }
 
 func (t *xmlTime) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	e.EncodeToken(start)
 	e.EncodeToken(xml.CharData([]byte(t.UTC().Format(time.RFC822))))
-	e.EncodeToken(xml.EndElement{start.Name})
+	e.EncodeToken(xml.EndElement{Name: start.Name})
 	return nil
 }
 
+// UnmarshalXML records the text of the element; a date that cannot be parsed
+// is not an error, as one bad item should not fail a whole feed
 func (t *xmlTime) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	var raw string
 
@@ -26,25 +31,14 @@ func (t *xmlTime) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	if err != nil {
 		return err
 	}
-	date, err := time.Parse(time.RFC1123Z, raw)
+	raw = strings.TrimSpace(raw)
+	date, _ := defaultDateParser.Parse(raw)
 
-	if err != nil {
-		return err
-	}
-
-	*t = xmlTime{date}
+	*t = xmlTime{Time: date, Raw: raw}
 	return nil
-
 }
 
+// parseDate parses a date with the default DateParser
 func parseDate(date string) (time.Time, error) {
-	formats := []string{time.RFC3339, time.RFC1123Z}
-	var parsedTime time.Time
-	var err error
-	for _, format := range formats {
-		if parsedTime, err = time.Parse(format, date); err == nil {
-			return parsedTime, nil
-		}
-	}
-	return parsedTime, errors.Errorf("failed to parse date %s as one of %s", date, strings.Join(formats, ", "))
+	return defaultDateParser.Parse(date)
 }
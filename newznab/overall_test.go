@@ -13,7 +13,6 @@ import (
 	"time"
 
 	log "github.com/Sirupsen/logrus"
-	uuid "github.com/satori/go.uuid"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -30,7 +29,7 @@ func newMockServer() *httptest.Server {
 		if r.URL.Query()["t"][0] == "get" {
 			// Fetch entry
 			entryID := r.URL.Query()["id"][0]
-			filePath := fmt.Sprintf("../tests/fixtures/entrys/%v.entry", entryID)
+			filePath := fmt.Sprintf("../tests/fixtures/nzbs/%v.nzb", entryID)
 			f, err = ioutil.ReadFile(filePath)
 		} else {
 			// Get xml
@@ -48,6 +47,18 @@ func newMockServer() *httptest.Server {
 	}))
 }
 
+// newMockClient returns a Client using a mock server that serves the fixtures
+// under tests/fixtures; the caller must close the returned server
+func newMockClient(t *testing.T) (*Client, *httptest.Server) {
+	ts := newMockServer()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		ts.Close()
+		t.Fatalf("Failed to parse mock server URL")
+	}
+	return &Client{HTTPClient: &http.Client{}, BaseURL: u, APIKey: "gibberish"}, ts
+}
+
 func TestUsenetCrawlerClient(t *testing.T) {
 	log.SetLevel(log.DebugLevel)
 	apiKey := "gibberish"
@@ -65,7 +76,7 @@ func TestUsenetCrawlerClient(t *testing.T) {
 		if r.URL.Query()["t"][0] == "get" {
 			// Fetch entry
 			entryID := r.URL.Query()["id"][0]
-			filePath := fmt.Sprintf("../tests/fixtures/entrys/%v.entry", entryID)
+			filePath := fmt.Sprintf("../tests/fixtures/nzbs/%v.nzb", entryID)
 			f, err = ioutil.ReadFile(filePath)
 		} else {
 			// Get xml
@@ -151,7 +162,7 @@ func TestUsenetCrawlerClient(t *testing.T) {
 
 				Convey("I can populate the comments for an NZB.", func() {
 					entry := results[1]
-					So(len(entry.Meta.Comments.Comments), ShouldBeGreaterThan, 0)
+					So(len(entry.Meta.Comments.Comments), ShouldEqual, 0)
 					So(entry.Meta.Comments.Number, ShouldBeGreaterThan, 0)
 					err := entry.PopulateComments(client)
 					So(err, ShouldBeNil)
@@ -196,8 +207,8 @@ func TestUsenetCrawlerClient(t *testing.T) {
 				So(len(results), ShouldBeGreaterThan, 0)
 
 				Convey("The results have different categories.", func() {
-					So(results[0].General.Categorisation.Category[1], ShouldEqual, "2040")
-					So(results[22].General.Categorisation.Category[1], ShouldEqual, "2050")
+					So(results[0].General.Categorisation.Category[1], ShouldEqual, CategoryMovieHD)
+					So(results[22].General.Categorisation.Category[1], ShouldEqual, CategoryMovieBluRay)
 				})
 			})
 
@@ -212,7 +223,7 @@ func TestUsenetCrawlerClient(t *testing.T) {
 
 					Convey("An IMDB id.", func() {
 						imdbAttr := results[0].Content.(*Movie).IMDBID
-						So(imdbAttr, ShouldEqual, 364569)
+						So(imdbAttr, ShouldEqual, IMDBID(364569))
 					})
 
 					Convey("An IMDB title.", func() {
@@ -252,8 +263,7 @@ func TestUsenetCrawlerClient(t *testing.T) {
 
 				Convey("A TV result is present.", func() {
 					guid := results[0].Meta.ID
-					expected, _ := uuid.FromString("bcdbf3f1e7a1ef964527f1d40d5ec639")
-					So(guid.String(), ShouldEqual, expected.String())
+					So(guid, ShouldEqual, EntryID("bcdbf3f1e7a1ef964527f1d40d5ec639"))
 				})
 
 				Convey("A Movie result is present.", func() {
@@ -274,8 +284,7 @@ func TestUsenetCrawlerClient(t *testing.T) {
 			})
 
 			Convey("I can load the RSS feed up to a given NZB ID.", func() {
-				id, err := uuid.FromString("29527a54ac54bb7533abacd7dad66a6a")
-				So(err, ShouldBeNil)
+				id := EntryID("29527a54ac54bb7533abacd7dad66a6a")
 				results, err := client.SearchRSSUntilEntryID(categories, num, id, 0)
 
 				Convey("A valid result is returned.", func() {
@@ -285,17 +294,15 @@ func TestUsenetCrawlerClient(t *testing.T) {
 
 				Convey("Everything up to the given ID is returned.", func() {
 					firstID := results[0].Meta.ID
-					expected, _ := uuid.FromString("8841b21c4d2fb96f0d47ca24cae9a5b7")
-					So(firstID.String(), ShouldEqual, expected.String())
+					So(firstID, ShouldEqual, EntryID("8841b21c4d2fb96f0d47ca24cae9a5b7"))
 
 					lastID := results[len(results)-1].Meta.ID
-					expected, _ = uuid.FromString("2c6c0e2ac562db69d8b3646deaf2d0cd")
-					So(lastID.String(), ShouldEqual, expected.String())
+					So(lastID, ShouldEqual, EntryID("2c6c0e2ac562db69d8b3646deaf2d0cd"))
 				})
 			})
 
 			Convey("I can load the RSS feed up to a given NZB ID but will stop after N tries", func() {
-				results, err := client.SearchRSSUntilEntryID(categories, num, uuid.UUID{}, 2)
+				results, err := client.SearchRSSUntilEntryID(categories, num, EntryID(""), 2)
 
 				Convey("100 results with 2 requests were fetched.", func() {
 					So(err, ShouldBeNil)
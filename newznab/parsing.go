@@ -1,6 +1,7 @@
 package newznab
 
 import (
+	"context"
 	"encoding/xml"
 	"net/url"
 
@@ -9,28 +10,38 @@ import (
 
 // contains functions relating to the processing of newznab responses
 
-// entriesFromURL extracts newznab Entries from the response body returned
-// from the given URL.  entriesFromURL performs a GET request against the
-// given URL, and parses the response body, ultimately returning Entries.
-func (c *Client) entriesFromURL(u *url.URL) (entries Entries, err error) {
-	rsp, err := c.getURLResponseBody(u)
+// feedFromURL extracts newznab Entries from the response body returned from
+// the given URL.  feedFromURL performs a GET request against the given URL,
+// and parses the response body, returning the parsed feed and the Entries
+// produced from it, so that response level information such as the total
+// number of results is available to the caller.  Any enrichments requested in
+// opts are performed on the resulting Entries.
+func (c *Client) feedFromURL(ctx context.Context, u *url.URL, opts SearchOptions) (feed *rawEntries, entries Entries, err error) {
+	rsp, err := c.getURLResponseBody(ctx, RequestAPI, u)
 	if err != nil {
-		return nil, errors.Wrap(err, 1)
+		return nil, nil, err
 	}
 
-	feed := new(rawEntries)
+	feed = new(rawEntries)
 	err = xml.Unmarshal(rsp, feed)
 	if err != nil {
-		return nil, errors.Wrapf(err, "error unmarshalling XML response into rawEntries", 1)
+		return nil, nil, errors.Wrapf(err, "error unmarshalling XML response into rawEntries", 1)
 	}
-	if feed.ErrorCode != 0 {
-		return nil, errors.Errorf("response body contained error %d: %s", feed.ErrorCode, feed.ErrorDesc)
+
+	// some indexers report their view of our usage with every response
+	if limits := feed.Channel.APILimits; c.Quota != nil {
+		if limits.APIMax > 0 {
+			c.Quota.sync(RequestAPI, limits.APICurrent, limits.APIMax)
+		}
+		if limits.GrabMax > 0 {
+			c.Quota.sync(RequestDownload, limits.GrabCurrent, limits.GrabMax)
+		}
 	}
 
-	entries, err = rawEntriesToEntries(c, *feed)
+	entries, err = rawEntriesToEntries(ctx, c, *feed, opts)
 	if err != nil {
-		return nil, errors.Wrapf(err, "error converting rawEntries into Entries", 1)
+		return nil, nil, errors.Wrapf(err, "error converting rawEntries into Entries", 1)
 	}
 
-	return entries, nil
+	return feed, entries, nil
 }
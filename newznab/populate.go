@@ -0,0 +1,176 @@
+package newznab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultEnrichmentConcurrency is the number of Entries enriched concurrently
+// when no concurrency limit is specified
+const DefaultEnrichmentConcurrency = 4
+
+// PopulateOptions describes options for Entries.Populate
+type PopulateOptions struct {
+	// enrichments to perform on each Entry
+	Enrichments Enrichment
+	// maximum number of Entries to enrich concurrently; if zero or negative,
+	// DefaultEnrichmentConcurrency is used
+	Concurrency int
+}
+
+// PopulateError describes the failure of a single enrichment of a single Entry
+type PopulateError struct {
+	// index of the Entry within Entries that failed to be enriched
+	Index int
+	// enrichment that failed
+	Enrichment Enrichment
+	// underlying error
+	Err error
+}
+
+// Error implements the error interface for PopulateError
+func (e PopulateError) Error() string {
+	var what string
+	switch e.Enrichment {
+	case EnrichComments:
+		what = "comments"
+	case EnrichFile:
+		what = "file"
+	default:
+		what = "entry"
+	}
+	return fmt.Sprintf("error populating %s for entry %d: %v", what, e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e PopulateError) Unwrap() error { return e.Err }
+
+// PopulateErrors is a []PopulateError slice that implements the error
+// interface, describing every enrichment that failed during Entries.Populate
+type PopulateErrors []PopulateError
+
+// Error implements the error interface for PopulateErrors
+func (es PopulateErrors) Error() string {
+	msgs := make([]string, 0, len(es))
+	for _, e := range es {
+		msgs = append(msgs, e.Error())
+	}
+	return fmt.Sprintf("%d enrichments failed: %s", len(es), strings.Join(msgs, "; "))
+}
+
+// As finds the first failed enrichment whose error matches target, as with
+// errors.As, so that callers may inspect the cause of each failure
+func (es PopulateErrors) As(target interface{}) bool {
+	for _, e := range es {
+		if errors.As(e, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Is reports whether the error of any failed enrichment matches target, as
+// with errors.Is
+func (es PopulateErrors) Is(target error) bool {
+	for _, e := range es {
+		if errors.Is(e, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Populate performs the enrichments requested in opts on every Entry, using a
+// pool of at most opts.Concurrency workers.  A failed enrichment does not stop
+// the others; instead every failure is collected and returned as
+// PopulateErrors.  If ctx is cancelled, Entries not yet enriched are recorded
+// as failed with the context's error.
+func (es Entries) Populate(ctx context.Context, c *Client, opts PopulateOptions) error {
+	if opts.Enrichments == EnrichNone || len(es) == 0 {
+		return nil
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultEnrichmentConcurrency
+	}
+
+	var (
+		mu   sync.Mutex
+		errs PopulateErrors
+		wg   sync.WaitGroup
+	)
+	record := func(index int, enrichment Enrichment, err error) {
+		mu.Lock()
+		errs = append(errs, PopulateError{Index: index, Enrichment: enrichment, Err: err})
+		mu.Unlock()
+	}
+
+	indices := make(chan int)
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				entry := &es[i]
+				if opts.Enrichments.Has(EnrichComments) {
+					if err := entry.PopulateCommentsContext(ctx, c); err != nil {
+						record(i, EnrichComments, err)
+					}
+				}
+				if opts.Enrichments.Has(EnrichFile) {
+					if err := entry.PopulateFileContext(ctx, c); err != nil {
+						record(i, EnrichFile, err)
+					}
+				}
+			}
+		}()
+	}
+
+	// entries that are never dispatched fail every requested enrichment, as
+	// they would had a worker attempted them
+	cancelled := func(from int) {
+		for j := from; j < len(es); j++ {
+			for _, enrichment := range []Enrichment{EnrichComments, EnrichFile} {
+				if opts.Enrichments.Has(enrichment) {
+					record(j, enrichment, ctx.Err())
+				}
+			}
+		}
+	}
+
+dispatch:
+	for i := range es {
+		// select chooses randomly between ready cases, so check for
+		// cancellation first rather than racing it against idle workers
+		select {
+		case <-ctx.Done():
+			cancelled(i)
+			break dispatch
+		default:
+		}
+		select {
+		case indices <- i:
+		case <-ctx.Done():
+			cancelled(i)
+			break dispatch
+		}
+	}
+	close(indices)
+	wg.Wait()
+
+	if len(errs) == 0 {
+		return nil
+	}
+	// order errors by entry, so that they do not depend on scheduling
+	sort.Slice(errs, func(i, j int) bool {
+		if errs[i].Index != errs[j].Index {
+			return errs[i].Index < errs[j].Index
+		}
+		return errs[i].Enrichment < errs[j].Enrichment
+	})
+	return errs
+}
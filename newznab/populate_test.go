@@ -0,0 +1,91 @@
+package newznab
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"testing"
+)
+
+func TestEntriesPopulate(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+	results, err := client.SearchWithTVRage([]Category{CategoryTVSD}, 2870, 10, 1)
+	if err != nil {
+		t.Fatalf("Failed to search mock indexer; %v", err)
+	}
+
+	err = results.Populate(context.Background(), client, PopulateOptions{
+		Enrichments: EnrichComments,
+		Concurrency: 3,
+	})
+	if len(results[1].Meta.Comments.Comments) == 0 {
+		t.Errorf("Comments were not populated for entry 1")
+	}
+
+	// only entry 1 has a comments fixture, so every other entry should fail
+	errs, ok := err.(PopulateErrors)
+	if !ok {
+		t.Fatalf("Populate should have returned PopulateErrors; got %v", err)
+	}
+	if len(errs) != len(results)-1 {
+		t.Errorf("Wrong number of errors; got %d expected %d", len(errs), len(results)-1)
+	}
+	for k, e := range errs {
+		if e.Index == 1 {
+			t.Errorf("Entry 1 should not have errored; %v", e)
+		}
+		if e.Enrichment != EnrichComments {
+			t.Errorf("Wrong enrichment for error %d; got %v expected %v", k, e.Enrichment, EnrichComments)
+		}
+		if k > 0 && errs[k-1].Index > e.Index {
+			t.Errorf("Errors are not ordered by entry index")
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = results.Populate(ctx, client, PopulateOptions{Enrichments: EnrichComments})
+	if errs, ok := err.(PopulateErrors); !ok || len(errs) != len(results) {
+		t.Errorf("Every entry should have failed with a cancelled context; got %v", err)
+	}
+
+	err = results.Populate(ctx, client, PopulateOptions{Enrichments: EnrichAll})
+	errs, ok = err.(PopulateErrors)
+	if !ok || len(errs) != 2*len(results) {
+		t.Fatalf("Every enrichment of every entry should have failed with a cancelled context; got %v", err)
+	}
+	for k, e := range errs {
+		if expected := []Enrichment{EnrichComments, EnrichFile}[k%2]; e.Enrichment != expected || e.Index != k/2 {
+			t.Errorf("Wrong error %d; got enrichment %v for entry %d", k, e.Enrichment, e.Index)
+		}
+		if e.Err != context.Canceled {
+			t.Errorf("Wrong cause for error %d; got %v", k, e.Err)
+		}
+	}
+}
+
+// populateOnlyFile is a File that does not implement ContextPopulator
+type populateOnlyFile struct {
+	populated bool
+}
+
+func (f *populateOnlyFile) Size() uint64                    { return 0 }
+func (f *populateOnlyFile) URL() *url.URL                   { return nil }
+func (f *populateOnlyFile) Bytes() ([]byte, error)          { return nil, nil }
+func (f *populateOnlyFile) BytesReader() (io.Reader, error) { return nil, nil }
+func (f *populateOnlyFile) Populate(c *Client, e *Entry) error {
+	f.populated = true
+	return nil
+}
+
+func TestPopulateFileContextWithoutContextPopulator(t *testing.T) {
+	f := new(populateOnlyFile)
+	e := &Entry{File: f}
+	if err := e.PopulateFileContext(context.Background(), &Client{}); err != nil {
+		t.Fatalf("Failed to populate file; %v", err)
+	}
+	if !f.populated {
+		t.Errorf("Populate should have been called for a File without PopulateContext")
+	}
+}
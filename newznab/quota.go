@@ -0,0 +1,253 @@
+package newznab
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RequestKind distinguishes the budgets that newznab indexers enforce
+// separately: API hits, such as searches, and downloads (grabs) of NZB or
+// torrent files
+type RequestKind int
+
+// RequestKind constants
+const (
+	// RequestAPI is an API hit, e.g. a search or caps request
+	RequestAPI RequestKind = iota
+	// RequestDownload is a download of an NZB or torrent file
+	RequestDownload
+)
+
+// String returns a human readable name for the RequestKind
+func (k RequestKind) String() string {
+	switch k {
+	case RequestAPI:
+		return "API"
+	case RequestDownload:
+		return "download"
+	default:
+		return fmt.Sprintf("RequestKind(%d)", int(k))
+	}
+}
+
+// DefaultQuotaPeriod is the period over which quotas are counted when none is
+// specified; most indexers enforce daily limits
+const DefaultQuotaPeriod = 24 * time.Hour
+
+// QuotaExceededError is returned, without making a request, when a request
+// would exceed the Client's Quota
+type QuotaExceededError struct {
+	// kind of request that was refused
+	Kind RequestKind
+	// limit for that kind of request
+	Limit int
+	// time the quota resets
+	ResetAt time.Time
+}
+
+// Error implements the error interface for QuotaExceededError
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%v quota of %d requests exceeded; resets at %v", e.Kind, e.Limit, e.ResetAt.Format(time.RFC3339))
+}
+
+// QuotaHeaders names the response headers in which an indexer reports the
+// limit and remaining requests of a quota
+type QuotaHeaders struct {
+	// header holding the limit per period, e.g. X-DailyLimit
+	Limit string
+	// header holding the number of requests remaining in the period
+	Remaining string
+}
+
+// Quota tracks API hits and downloads against separate limits over a fixed
+// period, which starts with the first request and restarts with the first
+// request after it has elapsed.  Limits may be set directly, and are updated
+// from the indexer's capabilities, from apilimits information in search
+// responses, and from any response headers configured in Headers.  The zero
+// value has no limits, but still counts requests.
+type Quota struct {
+	mu sync.Mutex
+	// length of the period limits apply to; if zero, DefaultQuotaPeriod is used
+	Period time.Duration
+	// headers the indexer reports each kind of quota in, if any.  Only
+	// headers describing limits over Period should be given; e.g. the common
+	// X-RateLimit-* headers usually describe a much shorter window.
+	Headers map[RequestKind]QuotaHeaders
+	// limits per period for each kind of request; zero means unlimited
+	limits map[RequestKind]int
+	// number of requests of each kind made in the current period
+	used map[RequestKind]int
+	// kinds of request the indexer has reported as exhausted for the current
+	// period
+	exhausted map[RequestKind]bool
+	// start of the current period
+	periodStart time.Time
+	// returns the current time; replaceable for tests
+	now func() time.Time
+}
+
+// NewQuota returns a Quota with the given limits per DefaultQuotaPeriod for
+// API hits and downloads; zero means unlimited
+func NewQuota(apiLimit, downloadLimit int) *Quota {
+	q := new(Quota)
+	q.SetLimit(RequestAPI, apiLimit)
+	q.SetLimit(RequestDownload, downloadLimit)
+	return q
+}
+
+// SetLimit sets the limit per period for the given kind of request; zero means
+// unlimited
+func (q *Quota) SetLimit(kind RequestKind, limit int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.limits == nil {
+		q.limits = make(map[RequestKind]int)
+	}
+	q.limits[kind] = limit
+}
+
+// Limit returns the limit per period for the given kind of request
+func (q *Quota) Limit(kind RequestKind) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.limits[kind]
+}
+
+// Used returns the number of requests of the given kind made in the current
+// period
+func (q *Quota) Used(kind RequestKind) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.rollover()
+	return q.used[kind]
+}
+
+// Remaining returns the number of requests of the given kind that may still
+// be made in the current period, or -1 if unlimited
+func (q *Quota) Remaining(kind RequestKind) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.rollover()
+	if q.exhausted[kind] {
+		return 0
+	}
+	limit := q.limits[kind]
+	if limit == 0 {
+		return -1
+	}
+	if remaining := limit - q.used[kind]; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// acquire counts a request of the given kind, or returns a
+// *QuotaExceededError if it would exceed the limit
+func (q *Quota) acquire(kind RequestKind) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.rollover()
+	if limit := q.limits[kind]; q.exhausted[kind] || (limit != 0 && q.used[kind] >= limit) {
+		return &QuotaExceededError{Kind: kind, Limit: limit, ResetAt: q.periodStart.Add(q.period())}
+	}
+	if q.used == nil {
+		q.used = make(map[RequestKind]int)
+	}
+	q.used[kind]++
+	return nil
+}
+
+// sync updates the usage and limit for the given kind of request from
+// information reported by the indexer
+func (q *Quota) sync(kind RequestKind, used, limit int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.rollover()
+	if q.limits == nil {
+		q.limits = make(map[RequestKind]int)
+	}
+	if q.used == nil {
+		q.used = make(map[RequestKind]int)
+	}
+	if limit > 0 {
+		q.limits[kind] = limit
+	}
+	q.used[kind] = used
+}
+
+// release returns a request of the given kind counted by acquire, e.g.
+// because it never reached the indexer
+func (q *Quota) release(kind RequestKind) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.rollover()
+	if q.used[kind] > 0 {
+		q.used[kind]--
+	}
+}
+
+// exhaust marks the quota for the given kind of request as used up until the
+// end of the current period, e.g. because the indexer reported it as reached.
+// The limit is left unchanged, so requests are allowed again in the next
+// period.
+func (q *Quota) exhaust(kind RequestKind) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.rollover()
+	if q.exhausted == nil {
+		q.exhausted = make(map[RequestKind]bool)
+	}
+	q.exhausted[kind] = true
+}
+
+// syncHeader updates the usage and limit for the given kind of request from
+// the response headers configured for it in Headers, if present
+func (q *Quota) syncHeader(kind RequestKind, header http.Header) {
+	names, ok := q.Headers[kind]
+	if !ok {
+		return
+	}
+	limit, err := strconv.Atoi(header.Get(names.Limit))
+	if err != nil || limit <= 0 {
+		return
+	}
+	remaining, err := strconv.Atoi(header.Get(names.Remaining))
+	if err != nil || remaining < 0 {
+		return
+	}
+	used := limit - remaining
+	if used < 0 {
+		used = 0
+	}
+	q.sync(kind, used, limit)
+}
+
+// rollover starts a new period if the current one has elapsed.  The caller
+// must hold mu.
+func (q *Quota) rollover() {
+	now := q.clock()
+	if q.periodStart.IsZero() || !now.Before(q.periodStart.Add(q.period())) {
+		q.periodStart = now
+		q.used = nil
+		q.exhausted = nil
+	}
+}
+
+// period returns the length of the quota period
+func (q *Quota) period() time.Duration {
+	if q.Period == 0 {
+		return DefaultQuotaPeriod
+	}
+	return q.Period
+}
+
+// clock returns the current time
+func (q *Quota) clock() time.Time {
+	if q.now != nil {
+		return q.now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,202 @@
+package newznab
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestQuota(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`<rss version="2.0"><channel></channel></rss>`))
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	client := &Client{HTTPClient: &http.Client{}, BaseURL: u, Quota: NewQuota(2, 1)}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.SearchContext(context.Background(), url.Values{"t": []string{"search"}}); err != nil {
+			t.Fatalf("Search %d should have succeeded; %v", i, err)
+		}
+	}
+	_, err := client.SearchContext(context.Background(), url.Values{"t": []string{"search"}})
+	var quotaErr *QuotaExceededError
+	if !errors.As(err, &quotaErr) || quotaErr.Kind != RequestAPI {
+		t.Errorf("Expected an API *QuotaExceededError; got %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("Request over quota should not have been made; got %d requests", n)
+	}
+
+	// downloads are counted separately from API hits
+	if _, err := client.DownloadEntryContext(context.Background(), Entry{}); err != nil {
+		t.Errorf("Download should have succeeded; %v", err)
+	}
+	if _, err := client.DownloadEntryContext(context.Background(), Entry{}); !errors.As(err, &quotaErr) || quotaErr.Kind != RequestDownload {
+		t.Errorf("Expected a download *QuotaExceededError; got %v", err)
+	}
+	if r := client.Quota.Remaining(RequestAPI); r != 0 {
+		t.Errorf("Wrong remaining API quota; got %d expected %d", r, 0)
+	}
+}
+
+func TestQuotaRollover(t *testing.T) {
+	now := time.Date(2017, time.May, 3, 16, 33, 36, 0, time.UTC)
+	q := NewQuota(1, 0)
+	q.now = func() time.Time { return now }
+
+	if err := q.acquire(RequestAPI); err != nil {
+		t.Fatalf("First request should be within quota; %v", err)
+	}
+	if err := q.acquire(RequestAPI); err == nil {
+		t.Errorf("Second request should exceed quota")
+	}
+	if err := q.acquire(RequestDownload); err != nil {
+		t.Errorf("Unlimited downloads should not be refused; %v", err)
+	}
+	if r := q.Remaining(RequestDownload); r != -1 {
+		t.Errorf("Unlimited quota should report -1 remaining; got %d", r)
+	}
+
+	now = now.Add(DefaultQuotaPeriod)
+	if err := q.acquire(RequestAPI); err != nil {
+		t.Errorf("Quota should have reset after a period; %v", err)
+	}
+}
+
+func TestQuotaSyncFromResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<rss version="2.0" xmlns:newznab="http://www.newznab.com/DTD/2010/feeds/attributes/"><channel>` +
+			`<newznab:apilimits apicurrent="97" apimax="100" grabcurrent="3" grabmax="25"/></channel></rss>`))
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	client := &Client{HTTPClient: &http.Client{}, BaseURL: u, Quota: new(Quota)}
+
+	if _, err := client.SearchContext(context.Background(), url.Values{"t": []string{"search"}}); err != nil {
+		t.Fatalf("Search should have succeeded; %v", err)
+	}
+	if r := client.Quota.Remaining(RequestAPI); r != 3 {
+		t.Errorf("Wrong remaining API quota; got %d expected %d", r, 3)
+	}
+	if r := client.Quota.Remaining(RequestDownload); r != 22 {
+		t.Errorf("Wrong remaining download quota; got %d expected %d", r, 22)
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	b := NewTokenBucket(1000, 2)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := b.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait failed; %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < time.Millisecond {
+		t.Errorf("Requests beyond the burst should have been delayed; took %v", elapsed)
+	}
+
+	b = NewTokenBucket(0.001, 1)
+	b.Wait(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := b.Wait(ctx); err == nil {
+		t.Errorf("Wait should have failed when the context expired")
+	}
+}
+
+func TestQuotaExhaust(t *testing.T) {
+	now := time.Date(2017, time.May, 3, 16, 33, 36, 0, time.UTC)
+	q := new(Quota)
+	q.now = func() time.Time { return now }
+
+	if err := q.acquire(RequestAPI); err != nil {
+		t.Fatalf("Request without a limit should be allowed; %v", err)
+	}
+	q.exhaust(RequestAPI)
+	if err := q.acquire(RequestAPI); err == nil {
+		t.Errorf("Request should be refused once the quota is exhausted")
+	}
+	if r := q.Remaining(RequestAPI); r != 0 {
+		t.Errorf("Exhausted quota should report 0 remaining; got %d", r)
+	}
+
+	now = now.Add(DefaultQuotaPeriod)
+	for i := 0; i < 3; i++ {
+		if err := q.acquire(RequestAPI); err != nil {
+			t.Errorf("Request %d should be allowed in the next period; %v", i, err)
+		}
+	}
+	if l := q.Limit(RequestAPI); l != 0 {
+		t.Errorf("Exhausting the quota should not set a limit; got %d", l)
+	}
+}
+
+func TestQuotaSyncFromHeader(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "40")
+		w.Write([]byte(`<rss version="2.0"><channel></channel></rss>`))
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	client := &Client{HTTPClient: &http.Client{}, BaseURL: u, Quota: new(Quota)}
+
+	// headers are only used once configured
+	if _, err := client.SearchContext(context.Background(), url.Values{"t": []string{"search"}}); err != nil {
+		t.Fatalf("Search should have succeeded; %v", err)
+	}
+	if r := client.Quota.Remaining(RequestAPI); r != -1 {
+		t.Errorf("Unconfigured headers should be ignored; got %d remaining", r)
+	}
+
+	client.Quota.Headers = map[RequestKind]QuotaHeaders{
+		RequestAPI: {Limit: "X-RateLimit-Limit", Remaining: "X-RateLimit-Remaining"},
+	}
+	if _, err := client.SearchContext(context.Background(), url.Values{"t": []string{"search"}}); err != nil {
+		t.Fatalf("Search should have succeeded; %v", err)
+	}
+	if r := client.Quota.Remaining(RequestAPI); r != 40 {
+		t.Errorf("Wrong remaining API quota; got %d expected %d", r, 40)
+	}
+	if r := client.Quota.Remaining(RequestDownload); r != -1 {
+		t.Errorf("Download quota should not be affected by API headers; got %d", r)
+	}
+}
+
+func TestQuotaNotUsedWithoutResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	u, _ := url.Parse(ts.URL)
+	ts.Close()
+	client := &Client{
+		HTTPClient:  &http.Client{},
+		BaseURL:     u,
+		Quota:       NewQuota(10, 0),
+		RateLimiter: NewTokenBucket(0.001, 1),
+	}
+
+	// the connection is refused by the closed server
+	if _, err := client.SearchContext(context.Background(), url.Values{"t": []string{"search"}}); err == nil {
+		t.Fatalf("Search of a closed server should have failed")
+	}
+	if used := client.Quota.Used(RequestAPI); used != 0 {
+		t.Errorf("A failed connection should not use the quota; got %d used", used)
+	}
+
+	// the only token has been taken, so the wait is cancelled
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.SearchContext(ctx, url.Values{"t": []string{"search"}}); err == nil {
+		t.Fatalf("Search should have failed waiting for the rate limiter")
+	}
+	if used := client.Quota.Used(RequestAPI); used != 0 {
+		t.Errorf("A cancelled wait should not use the quota; got %d used", used)
+	}
+}
@@ -0,0 +1,81 @@
+package newznab
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter limits the rate at which a Client makes requests.  Wait blocks
+// until a request may be made, or returns an error if the context is done
+// first.  *rate.Limiter from golang.org/x/time/rate satisfies this interface,
+// as does TokenBucket.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// TokenBucket is a simple token bucket RateLimiter.  Tokens are added at a
+// fixed rate up to a maximum burst, and each request consumes one token.
+type TokenBucket struct {
+	mu sync.Mutex
+	// number of tokens added per second
+	rate float64
+	// maximum number of tokens the bucket holds
+	burst float64
+	// number of tokens currently in the bucket
+	tokens float64
+	// time tokens were last added
+	last time.Time
+}
+
+// NewTokenBucket returns a TokenBucket that allows on average rate requests per
+// second, with bursts of up to burst requests.  The bucket starts full.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait implements RateLimiter for TokenBucket
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	for {
+		delay := b.reserve()
+		if delay == 0 {
+			return nil
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a token if one is available and returns zero, else returns
+// how long until the next token is available
+func (b *TokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	if b.rate <= 0 {
+		// a bucket that is never refilled; poll infrequently until the
+		// context gives up
+		return time.Second
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
@@ -0,0 +1,181 @@
+package newznab
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy decides whether, and after how long, a failed request should be
+// retried.  It applies to every request made by a Client, except those with
+// side effects on the indexer; see nonIdempotentFunctions.
+type RetryPolicy interface {
+	// Retry is called after the given attempt (starting at 1) failed with err.
+	// It returns how long to wait before the next attempt, and whether another
+	// attempt should be made at all.
+	Retry(attempt int, err error) (time.Duration, bool)
+}
+
+// nonIdempotentFunctions are the API functions that change state on the
+// indexer.  A failed attempt may still have taken effect, e.g. a registration
+// whose response was lost, so they are never retried.
+var nonIdempotentFunctions = map[string]bool{
+	FunctionRegister:   true,
+	FunctionCommentAdd: true,
+}
+
+// Default values used by ExponentialBackoff for unset fields
+const (
+	DefaultRetryMaxAttempts  = 4
+	DefaultRetryInitialDelay = 500 * time.Millisecond
+	DefaultRetryMaxDelay     = 30 * time.Second
+	DefaultRetryMultiplier   = 2
+)
+
+// ExponentialBackoff is a RetryPolicy that retries retryable errors with an
+// exponentially increasing, jittered delay.  A Retry-After header on the
+// failed response takes precedence over the computed delay.  The zero value
+// is usable, and uses the Default* values above.
+type ExponentialBackoff struct {
+	// total number of attempts to make, including the first
+	MaxAttempts int
+	// delay before the first retry
+	InitialDelay time.Duration
+	// upper bound on the delay between attempts
+	MaxDelay time.Duration
+	// factor by which the delay grows after each attempt
+	Multiplier float64
+	// fraction of the delay, between 0 and 1, by which it is randomly varied
+	Jitter float64
+	// if a Retry-After header asks the client to wait longer than this, the
+	// request is not retried; zero means no limit
+	MaxRetryAfter time.Duration
+	// decides which errors are retryable; if nil, IsRetryable is used
+	Retryable func(err error) bool
+}
+
+// Retry implements RetryPolicy for ExponentialBackoff
+func (b ExponentialBackoff) Retry(attempt int, err error) (time.Duration, bool) {
+	maxAttempts := b.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = DefaultRetryMaxAttempts
+	}
+	if attempt >= maxAttempts {
+		return 0, false
+	}
+	retryable := b.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+	if !retryable(err) {
+		return 0, false
+	}
+
+	if retryAfter, ok := RetryAfter(err); ok {
+		if b.MaxRetryAfter != 0 && retryAfter > b.MaxRetryAfter {
+			return 0, false
+		}
+		return retryAfter, true
+	}
+
+	initialDelay, maxDelay, multiplier := b.InitialDelay, b.MaxDelay, b.Multiplier
+	if initialDelay == 0 {
+		initialDelay = DefaultRetryInitialDelay
+	}
+	if maxDelay == 0 {
+		maxDelay = DefaultRetryMaxDelay
+	}
+	if multiplier == 0 {
+		multiplier = DefaultRetryMultiplier
+	}
+
+	delay := float64(initialDelay) * math.Pow(multiplier, float64(attempt-1))
+	if b.Jitter > 0 {
+		delay += delay * b.Jitter * (2*rand.Float64() - 1)
+	}
+	if delay > float64(maxDelay) {
+		delay = float64(maxDelay)
+	}
+	return time.Duration(delay), true
+}
+
+// IsRetryable reports whether err is likely to be transient: network errors,
+// HTTP 408, 429, 500, 502, 503 and 504 responses, and newznab 429 errors.
+// Cancelled or expired contexts are never retryable.
+func IsRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		switch httpErr.StatusCode {
+		case http.StatusRequestTimeout, http.StatusTooManyRequests,
+			http.StatusInternalServerError, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		default:
+			return false
+		}
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == ErrorCodeTooManyRequests
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// RetryAfter returns the delay requested by the Retry-After header of the
+// response that caused err, if any
+func RetryAfter(err error) (time.Duration, bool) {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Header == nil {
+		return 0, false
+	}
+	return parseRetryAfter(httpErr.Header.Get("Retry-After"), time.Now())
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date, relative to now
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := date.Sub(now); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// sleepContext waits for the given duration, returning early with the
+// context's error if it is cancelled
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
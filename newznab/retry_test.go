@@ -0,0 +1,191 @@
+package newznab
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newFlakyServer returns a server that fails the first n requests with the
+// given status code, then responds with an empty feed
+func newFlakyServer(n int32, status int, header http.Header) (*httptest.Server, *int32) {
+	requests := new(int32)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(requests, 1) <= n {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`<rss version="2.0"><channel></channel></rss>`))
+	}))
+	return ts, requests
+}
+
+func TestRetryPolicy(t *testing.T) {
+	ts, requests := newFlakyServer(2, http.StatusServiceUnavailable, nil)
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	client := &Client{
+		HTTPClient:  &http.Client{},
+		BaseURL:     u,
+		RetryPolicy: ExponentialBackoff{MaxAttempts: 3, InitialDelay: time.Millisecond},
+	}
+
+	_, err := client.SearchContext(context.Background(), url.Values{"t": []string{"search"}})
+	if err != nil {
+		t.Errorf("Search should have succeeded after retrying; %v", err)
+	}
+	if n := atomic.LoadInt32(requests); n != 3 {
+		t.Errorf("Wrong number of requests; got %d expected %d", n, 3)
+	}
+}
+
+func TestRetryPolicyGivesUp(t *testing.T) {
+	ts, requests := newFlakyServer(5, http.StatusServiceUnavailable, nil)
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	client := &Client{
+		HTTPClient:  &http.Client{},
+		BaseURL:     u,
+		RetryPolicy: ExponentialBackoff{MaxAttempts: 2, InitialDelay: time.Millisecond},
+	}
+
+	_, err := client.SearchContext(context.Background(), url.Values{"t": []string{"search"}})
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected a 503 *HTTPError; got %v", err)
+	}
+	if n := atomic.LoadInt32(requests); n != 2 {
+		t.Errorf("Wrong number of requests; got %d expected %d", n, 2)
+	}
+}
+
+func TestRetryPolicyNotRetryable(t *testing.T) {
+	ts, requests := newFlakyServer(1, http.StatusNotFound, nil)
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	client := &Client{
+		HTTPClient:  &http.Client{},
+		BaseURL:     u,
+		RetryPolicy: ExponentialBackoff{InitialDelay: time.Millisecond},
+	}
+
+	_, err := client.SearchContext(context.Background(), url.Values{"t": []string{"search"}})
+	if err == nil {
+		t.Errorf("Search should have failed without retrying")
+	}
+	if n := atomic.LoadInt32(requests); n != 1 {
+		t.Errorf("Wrong number of requests; got %d expected %d", n, 1)
+	}
+}
+
+func TestRetryPolicyCancelled(t *testing.T) {
+	ts, requests := newFlakyServer(1, http.StatusServiceUnavailable, nil)
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	client := &Client{
+		HTTPClient:  &http.Client{},
+		BaseURL:     u,
+		RetryPolicy: ExponentialBackoff{InitialDelay: time.Minute},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.SearchContext(ctx, url.Values{"t": []string{"search"}})
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected the context's error while waiting to retry; got %v", err)
+	}
+	if n := atomic.LoadInt32(requests); n != 1 {
+		t.Errorf("Wrong number of requests; got %d expected %d", n, 1)
+	}
+
+	// without a RetryPolicy the error of the failed request is returned
+	client.RetryPolicy = nil
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.SearchContext(cancelled, url.Values{"t": []string{"search"}})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected an error matching context.Canceled; got %v", err)
+	}
+}
+
+func TestRetryPolicyNonIdempotent(t *testing.T) {
+	ts, requests := newFlakyServer(1, http.StatusServiceUnavailable, nil)
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	client := &Client{
+		HTTPClient:  &http.Client{},
+		BaseURL:     u,
+		RetryPolicy: ExponentialBackoff{InitialDelay: time.Millisecond},
+	}
+
+	if _, err := client.RegisterContext(context.Background(), "tester@example.com"); err == nil {
+		t.Errorf("Register should have failed without retrying")
+	}
+	if n := atomic.LoadInt32(requests); n != 1 {
+		t.Errorf("Register was retried; got %d requests expected %d", n, 1)
+	}
+
+	atomic.StoreInt32(requests, 0)
+	if err := client.AddCommentContext(context.Background(), EntryID("85ae3c25b68a6f1870bc7f732b939045"), "Great release"); err == nil {
+		t.Errorf("AddComment should have failed without retrying")
+	}
+	if n := atomic.LoadInt32(requests); n != 1 {
+		t.Errorf("AddComment was retried; got %d requests expected %d", n, 1)
+	}
+}
+
+func TestRetryPolicyRetryAfter(t *testing.T) {
+	policy := ExponentialBackoff{InitialDelay: time.Millisecond, MaxRetryAfter: time.Minute}
+	err := &HTTPError{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"7"}},
+	}
+	delay, retry := policy.Retry(1, err)
+	if !retry || delay != 7*time.Second {
+		t.Errorf("Retry-After was not honoured; got %v, %v", delay, retry)
+	}
+
+	err.Header.Set("Retry-After", "3600")
+	if _, retry := policy.Retry(1, err); retry {
+		t.Errorf("Retry-After beyond MaxRetryAfter should not be retried")
+	}
+
+	now := time.Date(2017, time.May, 3, 16, 33, 36, 0, time.UTC)
+	delay, ok := parseRetryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now)
+	if !ok || delay != 30*time.Second {
+		t.Errorf("Failed to parse HTTP date Retry-After; got %v, %v", delay, ok)
+	}
+}
+
+func TestExponentialBackoffDelays(t *testing.T) {
+	policy := ExponentialBackoff{
+		MaxAttempts:  10,
+		InitialDelay: 100 * time.Millisecond,
+		MaxDelay:     time.Second,
+		Jitter:       0.5,
+	}
+	err := &HTTPError{StatusCode: http.StatusBadGateway}
+	for attempt := 1; attempt < 10; attempt++ {
+		delay, retry := policy.Retry(attempt, err)
+		if !retry {
+			t.Fatalf("Attempt %d should have been retried", attempt)
+		}
+		if delay > time.Second {
+			t.Errorf("Delay for attempt %d exceeds MaxDelay; got %v", attempt, delay)
+		}
+		if attempt == 1 && (delay < 50*time.Millisecond || delay > 150*time.Millisecond) {
+			t.Errorf("Delay for attempt 1 is outside jitter bounds; got %v", delay)
+		}
+	}
+	if _, retry := policy.Retry(10, err); retry {
+		t.Errorf("MaxAttempts was not honoured")
+	}
+}
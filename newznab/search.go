@@ -1,20 +1,47 @@
 package newznab
 
 import (
+	"context"
 	"net/url"
 	"strconv"
 )
 
 // Search performs an arbitrary API query against the torznab indexer, and
-// parses and returns the newznab entries the API responded with
+// parses and returns the newznab entries the API responded with.  Search and
+// SearchContext predate SearchResult, and return only its Entries; use
+// SearchWithOptions or Client.Do for the response metadata.
 func (c *Client) Search(values url.Values) (Entries, error) {
+	return c.SearchContext(context.Background(), values)
+}
+
+// SearchContext is like Search, but performs its requests with the given
+// context
+func (c *Client) SearchContext(ctx context.Context, values url.Values) (Entries, error) {
+	result, err := c.SearchWithOptions(ctx, values, c.searchOptions())
+	return result.Entries, err
+}
+
+// SearchWithOptions is like SearchContext, but uses the given SearchOptions
+// rather than the Client's defaults, and returns a SearchResult including the
+// response metadata
+func (c *Client) SearchWithOptions(ctx context.Context, values url.Values, opts SearchOptions) (SearchResult, error) {
 	values.Set("apikey", c.APIKey)
-	return c.entriesFromURL(c.buildURL(ModePathAPI, values))
+	opts.apply(values)
+	return c.searchResultFromURL(ctx, c.buildURL(ModePathAPI, values), opts)
 }
 
 // SearchWithTVRage returns NZBs for the given parameters
+//
+// Deprecated: use Client.Do with a SearchRequest, which supports the full set
+// of search parameters.
 func (c *Client) SearchWithTVRage(categories []Category, tvRageID int, season int, episode int) (Entries, error) {
-	return c.Search(url.Values{
+	return c.SearchWithTVRageContext(context.Background(), categories, tvRageID, season, episode)
+}
+
+// SearchWithTVRageContext is like SearchWithTVRage, but performs its requests
+// with the given context
+func (c *Client) SearchWithTVRageContext(ctx context.Context, categories []Category, tvRageID int, season int, episode int) (Entries, error) {
+	return c.SearchContext(ctx, url.Values{
 		"rid":     []string{strconv.Itoa(tvRageID)},
 		"cat":     stringifyCategories(categories),
 		"season":  []string{strconv.Itoa(season)},
@@ -24,8 +51,17 @@ func (c *Client) SearchWithTVRage(categories []Category, tvRageID int, season in
 }
 
 // SearchWithTVDB returns NZBs for the given parameters
+//
+// Deprecated: use Client.Do with a SearchRequest, which supports the full set
+// of search parameters.
 func (c *Client) SearchWithTVDB(categories []Category, tvDBID int, season int, episode int) (Entries, error) {
-	return c.Search(url.Values{
+	return c.SearchWithTVDBContext(context.Background(), categories, tvDBID, season, episode)
+}
+
+// SearchWithTVDBContext is like SearchWithTVDB, but performs its requests
+// with the given context
+func (c *Client) SearchWithTVDBContext(ctx context.Context, categories []Category, tvDBID int, season int, episode int) (Entries, error) {
+	return c.SearchContext(ctx, url.Values{
 		"tvdbid":  []string{strconv.Itoa(tvDBID)},
 		"cat":     stringifyCategories(categories),
 		"season":  []string{strconv.Itoa(season)},
@@ -35,8 +71,17 @@ func (c *Client) SearchWithTVDB(categories []Category, tvDBID int, season int, e
 }
 
 // SearchWithIMDB returns NZBs for the given parameters
+//
+// Deprecated: use Client.Do with a SearchRequest, which supports the full set
+// of search parameters.
 func (c *Client) SearchWithIMDB(categories []Category, imdbID string) (Entries, error) {
-	return c.Search(url.Values{
+	return c.SearchWithIMDBContext(context.Background(), categories, imdbID)
+}
+
+// SearchWithIMDBContext is like SearchWithIMDB, but performs its requests
+// with the given context
+func (c *Client) SearchWithIMDBContext(ctx context.Context, categories []Category, imdbID string) (Entries, error) {
+	return c.SearchContext(ctx, url.Values{
 		"imdbid": []string{imdbID},
 		"cat":    stringifyCategories(categories),
 		"t":      []string{"movie"},
@@ -44,8 +89,17 @@ func (c *Client) SearchWithIMDB(categories []Category, imdbID string) (Entries,
 }
 
 // SearchWithQuery returns NZBs for the given parameters
+//
+// Deprecated: use Client.Do with a SearchRequest, which supports the full set
+// of search parameters.
 func (c *Client) SearchWithQuery(categories []Category, query string, searchType string) (Entries, error) {
-	return c.Search(url.Values{
+	return c.SearchWithQueryContext(context.Background(), categories, query, searchType)
+}
+
+// SearchWithQueryContext is like SearchWithQuery, but performs its requests
+// with the given context
+func (c *Client) SearchWithQueryContext(ctx context.Context, categories []Category, query string, searchType string) (Entries, error) {
+	return c.SearchContext(ctx, url.Values{
 		"q":   []string{query},
 		"cat": stringifyCategories(categories),
 		"t":   []string{searchType},
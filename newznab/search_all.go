@@ -0,0 +1,205 @@
+package newznab
+
+import (
+	"context"
+)
+
+// DefaultPageSize is the number of results SearchAll requests per page when
+// neither the request nor the indexer's capabilities specify one
+const DefaultPageSize = 100
+
+// SearchIterator pages through the results of a SearchRequest, requesting
+// further pages with an increasing offset as required.  Use Next to advance
+// it, Entry to read the current Entry, and Err to check for errors once Next
+// returns false:
+//
+//	it := client.SearchAll(ctx, req, 0)
+//	for it.Next() {
+//		entry := it.Entry()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type SearchIterator struct {
+	client *Client
+	ctx    context.Context
+	req    SearchRequest
+	// maximum number of entries to return; zero means no limit
+	maxResults int
+	// number of entries requested per page; zero if unknown
+	pageSize int
+	// translates categories when the client maps categories; nil otherwise
+	mapper *CategoryMapper
+
+	// entries of the current page, and the position within them
+	page     Entries
+	position int
+	current  Entry
+	// number of entries in the current page as returned by the indexer,
+	// including any already returned from earlier pages
+	pageLength int
+
+	// offset of the next page to request
+	offset int
+	// total number of results reported by the indexer; -1 until known
+	total int
+	// number of entries returned by Next so far
+	returned int
+	// number of pages requested so far
+	pages int
+	// identifiers of the entries returned so far, used to detect indexers
+	// that ignore the offset parameter
+	seen map[string]struct{}
+	// whether the last page contained no entries not already returned
+	stalled bool
+
+	started bool
+	done    bool
+	err     error
+}
+
+// SearchAll returns a SearchIterator over every result of the given request.
+// The request's Limit, if set, is used as the page size, and its Offset as the
+// starting offset.  If maxResults is greater than zero, iteration stops after
+// that many entries.  The request is validated against the indexer's
+// capabilities before the first page is requested.
+func (c *Client) SearchAll(ctx context.Context, req SearchRequest, maxResults int) *SearchIterator {
+	return &SearchIterator{
+		client:     c,
+		ctx:        ctx,
+		req:        req,
+		maxResults: maxResults,
+		offset:     req.Offset,
+		total:      -1,
+	}
+}
+
+// Next advances the iterator to the next Entry, requesting another page if
+// required.  It returns false when there are no more entries, the caller's
+// limit has been reached, or an error occurred.
+func (it *SearchIterator) Next() bool {
+	if it.done || (it.maxResults > 0 && it.returned >= it.maxResults) {
+		it.done = true
+		return false
+	}
+	for it.position >= len(it.page) {
+		if !it.more() || !it.fetch() {
+			it.done = true
+			return false
+		}
+	}
+	it.current = it.page[it.position]
+	it.position++
+	it.returned++
+	return true
+}
+
+// Entry returns the current Entry
+func (it *SearchIterator) Entry() Entry { return it.current }
+
+// Err returns the error, if any, that stopped iteration
+func (it *SearchIterator) Err() error { return it.err }
+
+// Total returns the total number of results reported by the indexer, or -1
+// if no page has been fetched yet or the indexer does not report it
+func (it *SearchIterator) Total() int { return it.total }
+
+// Pages returns the number of pages requested so far
+func (it *SearchIterator) Pages() int { return it.pages }
+
+// more returns whether another page should be requested
+func (it *SearchIterator) more() bool {
+	if !it.started {
+		return true
+	}
+	if it.stalled {
+		return false
+	}
+	if it.total > 0 {
+		return it.offset < it.total
+	}
+	// the indexer does not report a total, so a short or empty page is the
+	// only indication that the results are exhausted; repeated entries still
+	// count towards the page, as the indexer did return them
+	return it.pageLength > 0 && it.pageLength >= it.pageSize
+}
+
+// fetch requests the next page, returning false on error or if the page is
+// empty
+func (it *SearchIterator) fetch() bool {
+	if !it.started {
+		it.started = true
+		caps, err := it.client.CapabilitiesContext(it.ctx)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if err = it.req.Validate(caps); err != nil {
+			it.err = err
+			return false
+		}
+		it.pageSize = it.req.Limit
+		if it.pageSize == 0 {
+			it.pageSize = caps.Limits.Default
+		}
+		if it.pageSize == 0 {
+			// without a known page size a short page cannot be recognised,
+			// so request pages of a known size
+			it.pageSize = DefaultPageSize
+			it.req.Limit = DefaultPageSize
+		}
+		it.seen = make(map[string]struct{})
+		if it.mapper = it.client.categoryMapper(caps); it.mapper != nil {
+			it.req.Categories = it.mapper.ToIndexer(it.req.Categories...)
+		}
+	}
+
+	req := it.req
+	req.Offset = it.offset
+	values := req.Values()
+	values.Set("apikey", it.client.APIKey)
+	opts := it.client.searchOptions()
+	opts.apply(values)
+	feed, entries, err := it.client.feedFromURL(it.ctx, it.client.buildURL(ModePathAPI, values), opts)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.pages++
+	if it.mapper != nil {
+		it.mapper.NormaliseEntries(entries)
+	}
+
+	if total := feed.Channel.Response.Total; total > 0 {
+		it.total = total
+	}
+	it.offset += len(entries)
+	it.pageLength = len(entries)
+
+	// an indexer that ignores offset returns the same page again; only
+	// entries not already returned are kept, and iteration stops once a page
+	// adds none
+	fresh := entries[:0]
+	for _, entry := range entries {
+		key := entryKey(entry)
+		if _, ok := it.seen[key]; ok {
+			continue
+		}
+		it.seen[key] = struct{}{}
+		fresh = append(fresh, entry)
+	}
+	it.stalled = len(fresh) == 0
+	it.page = fresh
+	it.position = 0
+	return len(fresh) > 0
+}
+
+// entryKey returns a string identifying the Entry within a set of search
+// results; its ID if it has one, and otherwise its link and title
+func entryKey(entry Entry) string {
+	if !entry.Meta.ID.IsZero() {
+		return "id:" + entry.Meta.ID.String()
+	}
+	return "item:" + entry.Item.Link + "\x00" + entry.Item.Title
+}
@@ -0,0 +1,184 @@
+package newznab
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+// newPagingServer returns a server with total results, that advertises its
+// total in responses only if reportTotal is set
+func newPagingServer(total int, reportTotal bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("t") == "caps" {
+			w.Write([]byte(`<caps><limits max="100" default="3"/><searching><search available="yes" supportedParams="q"/></searching></caps>`))
+			return
+		}
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		if limit == 0 {
+			limit = 3
+		}
+
+		buf := bytes.NewBufferString(`<rss version="2.0" xmlns:newznab="http://www.newznab.com/DTD/2010/feeds/attributes/"><channel>`)
+		if reportTotal {
+			fmt.Fprintf(buf, `<newznab:response offset="%d" total="%d"/>`, offset, total)
+		}
+		for i := offset; i < offset+limit && i < total; i++ {
+			fmt.Fprintf(buf, `<item><title>Entry %d</title><newznab:attr name="guid" value="%032x"/></item>`, i, i)
+		}
+		buf.WriteString(`</channel></rss>`)
+		w.Write(buf.Bytes())
+	}))
+}
+
+func TestSearchAll(t *testing.T) {
+	cases := []struct {
+		reportTotal bool
+		limit       int
+		maxResults  int
+		entries     int
+		pages       int
+	}{
+		{true, 0, 0, 7, 3},
+		{true, 5, 0, 7, 2},
+		{true, 3, 5, 5, 2},
+		{false, 0, 0, 7, 3},
+		{false, 2, 0, 7, 4},
+	}
+	for k, c := range cases {
+		ts := newPagingServer(7, c.reportTotal)
+		u, _ := url.Parse(ts.URL)
+		client := &Client{HTTPClient: &http.Client{}, BaseURL: u}
+
+		it := client.SearchAll(context.Background(), SearchRequest{Query: "entry", Limit: c.limit}, c.maxResults)
+		var titles []string
+		for it.Next() {
+			titles = append(titles, it.Entry().General.Title)
+		}
+		ts.Close()
+
+		if err := it.Err(); err != nil {
+			t.Errorf("Case %d errored; %v", k, err)
+			continue
+		}
+		if len(titles) != c.entries {
+			t.Errorf("Case %d returned wrong number of entries; got %d expected %d", k, len(titles), c.entries)
+		}
+		for i, title := range titles {
+			if expected := fmt.Sprintf("Entry %d", i); title != expected {
+				t.Errorf("Case %d returned entries out of order; got %v expected %v", k, title, expected)
+				break
+			}
+		}
+		if it.Pages() != c.pages {
+			t.Errorf("Case %d requested wrong number of pages; got %d expected %d", k, it.Pages(), c.pages)
+		}
+		if c.reportTotal && it.Total() != 7 {
+			t.Errorf("Case %d reported wrong total; got %d expected %d", k, it.Total(), 7)
+		}
+	}
+}
+
+func TestSearchAllValidationError(t *testing.T) {
+	ts := newPagingServer(7, true)
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	client := &Client{HTTPClient: &http.Client{}, BaseURL: u}
+
+	it := client.SearchAll(context.Background(), SearchRequest{Function: FunctionTVSearch, TVDBID: 1}, 0)
+	if it.Next() {
+		t.Errorf("Next should have returned false")
+	}
+	if _, ok := it.Err().(*SearchValidationError); !ok {
+		t.Errorf("Expected a *SearchValidationError; got %v", it.Err())
+	}
+}
+
+func TestSearchAllIgnoredOffset(t *testing.T) {
+	// an indexer without a default limit in its capabilities that returns
+	// the same full page whatever the offset
+	var limits []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("t") == "caps" {
+			w.Write([]byte(`<caps><searching><search available="yes" supportedParams="q"/></searching></caps>`))
+			return
+		}
+		limits = append(limits, query.Get("limit"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		buf := bytes.NewBufferString(`<rss version="2.0" xmlns:newznab="http://www.newznab.com/DTD/2010/feeds/attributes/"><channel>`)
+		for i := 0; i < limit; i++ {
+			fmt.Fprintf(buf, `<item><title>Entry %d</title><newznab:attr name="guid" value="%032x"/></item>`, i, i)
+		}
+		buf.WriteString(`</channel></rss>`)
+		w.Write(buf.Bytes())
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	client := &Client{HTTPClient: &http.Client{}, BaseURL: u}
+
+	it := client.SearchAll(context.Background(), SearchRequest{Query: "entry"}, 0)
+	entries := 0
+	for it.Next() {
+		entries++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Iteration errored; %v", err)
+	}
+	if entries != DefaultPageSize {
+		t.Errorf("Repeated entries should not be returned; got %d expected %d", entries, DefaultPageSize)
+	}
+	if it.Pages() != 2 {
+		t.Errorf("Iteration should stop after a page of repeated entries; got %d pages", it.Pages())
+	}
+	if len(limits) == 0 || limits[0] != strconv.Itoa(DefaultPageSize) {
+		t.Errorf("Pages should be requested with the default page size; got limits %v", limits)
+	}
+}
+
+func TestSearchAllOverlappingPages(t *testing.T) {
+	// an indexer without a total that repeats the last entry of the previous
+	// page at the start of each page
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("t") == "caps" {
+			w.Write([]byte(`<caps><limits max="100" default="3"/><searching><search available="yes" supportedParams="q"/></searching></caps>`))
+			return
+		}
+		start, _ := strconv.Atoi(query.Get("offset"))
+		if start > 0 {
+			start--
+		}
+		buf := bytes.NewBufferString(`<rss version="2.0" xmlns:newznab="http://www.newznab.com/DTD/2010/feeds/attributes/"><channel>`)
+		for i := start; i < start+3 && i < 7; i++ {
+			fmt.Fprintf(buf, `<item><title>Entry %d</title><newznab:attr name="guid" value="%032x"/></item>`, i, i)
+		}
+		buf.WriteString(`</channel></rss>`)
+		w.Write(buf.Bytes())
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	client := &Client{HTTPClient: &http.Client{}, BaseURL: u}
+
+	it := client.SearchAll(context.Background(), SearchRequest{Query: "entry"}, 0)
+	entries := 0
+	for it.Next() {
+		entries++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Iteration errored; %v", err)
+	}
+	if entries != 7 {
+		t.Errorf("A full page with repeated entries should not end iteration; got %d entries expected %d", entries, 7)
+	}
+	if it.Pages() != 3 {
+		t.Errorf("Wrong number of pages; got %d expected %d", it.Pages(), 3)
+	}
+}
@@ -0,0 +1,27 @@
+package newznab
+
+import (
+	"context"
+)
+
+// BookQuery describes the parameters of a book search
+type BookQuery struct {
+	// free text query
+	Query string
+	// author to search for
+	Author string
+	// title to search for
+	Title string
+}
+
+// SearchBook performs a book search (t=book) with the given parameters,
+// validated against the indexer's capabilities
+func (c *Client) SearchBook(ctx context.Context, categories []Category, q BookQuery) (SearchResult, error) {
+	return c.Do(ctx, SearchRequest{
+		Function:   FunctionBookSearch,
+		Categories: categories,
+		Query:      q.Query,
+		Author:     q.Author,
+		Title:      q.Title,
+	})
+}
@@ -0,0 +1,51 @@
+package newznab
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSearchBook(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+
+	result, err := client.SearchBook(context.Background(), []Category{CategoryBooksEbook}, BookQuery{
+		Author: "Frank Herbert",
+		Title:  "Dune",
+	})
+	if err != nil {
+		t.Fatalf("Failed to search mock indexer; %v", err)
+	}
+	if len(result.Entries) != 2 {
+		t.Fatalf("Wrong number of results; got %d expected %d", len(result.Entries), 2)
+	}
+
+	ebook, ok := result.Entries[0].Content.(*Book)
+	if !ok {
+		t.Fatalf("Content should be *Book; got %T", result.Entries[0].Content)
+	}
+	expected := Book{
+		PublishDate: ebook.PublishDate,
+		BookTitle:   "Dune",
+		Author:      "Frank Herbert",
+		Publisher:   "Ace",
+		Pages:       528,
+		ISBN:        "9780441013593",
+	}
+	if *ebook != expected {
+		t.Errorf("Wrong book content; got %+v expected %+v", *ebook, expected)
+	}
+	if ebook.Title() != "Dune" || ebook.Aired().Year() != 2005 {
+		t.Errorf("Wrong title or publish date; got %v, %v", ebook.Title(), ebook.Aired())
+	}
+
+	// the audiobook is in an audio category, but its publisher should still
+	// be attributed to a Book
+	audiobook, ok := result.Entries[1].Content.(*Book)
+	if !ok {
+		t.Fatalf("Content should be *Book; got %T", result.Entries[1].Content)
+	}
+	if audiobook.Publisher != "Macmillan Audio" || audiobook.Author != "Frank Herbert" {
+		t.Errorf("Wrong audiobook content; got %+v", *audiobook)
+	}
+}
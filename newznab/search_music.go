@@ -0,0 +1,39 @@
+package newznab
+
+import (
+	"context"
+)
+
+// MusicQuery describes the parameters of a music search
+type MusicQuery struct {
+	// free text query
+	Query string
+	// artist to search for
+	Artist string
+	// album to search for
+	Album string
+	// record label to search for
+	Label string
+	// track to search for
+	Track string
+	// year of release
+	Year int
+	// genre to restrict the search to
+	Genre string
+}
+
+// SearchMusic performs a music search (t=music) with the given parameters,
+// validated against the indexer's capabilities
+func (c *Client) SearchMusic(ctx context.Context, categories []Category, q MusicQuery) (SearchResult, error) {
+	return c.Do(ctx, SearchRequest{
+		Function:   FunctionMusicSearch,
+		Categories: categories,
+		Query:      q.Query,
+		Artist:     q.Artist,
+		Album:      q.Album,
+		Label:      q.Label,
+		Track:      q.Track,
+		Year:       q.Year,
+		Genre:      q.Genre,
+	})
+}
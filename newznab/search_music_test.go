@@ -0,0 +1,52 @@
+package newznab
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSearchMusic(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+
+	result, err := client.SearchMusic(context.Background(), []Category{CategoryAudioAll}, MusicQuery{
+		Artist: "Queen",
+		Album:  "A Night at the Opera",
+	})
+	if err != nil {
+		t.Fatalf("Failed to search mock indexer; %v", err)
+	}
+	if len(result.Entries) != 2 {
+		t.Fatalf("Wrong number of results; got %d expected %d", len(result.Entries), 2)
+	}
+
+	album, ok := result.Entries[0].Content.(*Music)
+	if !ok {
+		t.Fatalf("Content should be *Music; got %T", result.Entries[0].Content)
+	}
+	expected := Music{
+		ReleaseDate: album.ReleaseDate,
+		Artist:      "Queen",
+		Album:       "A Night at the Opera",
+		Label:       "Island",
+		Publisher:   "EMI",
+		Year:        1975,
+		Genre:       "Rock",
+	}
+	if *album != expected {
+		t.Errorf("Wrong music content; got %+v expected %+v", *album, expected)
+	}
+	if album.Title() != "A Night at the Opera" || album.Aired().Year() != 1975 {
+		t.Errorf("Wrong title or release date; got %v, %v", album.Title(), album.Aired())
+	}
+
+	// year precedes any music specific attribute in the second entry, so it
+	// is attributed using the entry's category
+	track, ok := result.Entries[1].Content.(*Music)
+	if !ok {
+		t.Fatalf("Content should be *Music; got %T", result.Entries[1].Content)
+	}
+	if track.Title() != "Bohemian Rhapsody" || track.Year != 1975 {
+		t.Errorf("Wrong track content; got %+v", *track)
+	}
+}
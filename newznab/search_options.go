@@ -0,0 +1,52 @@
+package newznab
+
+import "net/url"
+
+// Enrichment is a bit set describing additional information that may be
+// fetched for each Entry returned by a search.  Each enrichment costs at least
+// one extra request per Entry, so none are performed by default.
+type Enrichment uint
+
+// Enrichment constants
+const (
+	// EnrichComments fetches the comments for each Entry
+	EnrichComments Enrichment = 1 << iota
+	// EnrichFile downloads and parses the NZB or torrent file for each Entry
+	EnrichFile
+
+	// EnrichNone performs no enrichment
+	EnrichNone Enrichment = 0
+	// EnrichAll performs every enrichment
+	EnrichAll = EnrichComments | EnrichFile
+)
+
+// Has returns whether all of the given enrichments are set
+func (e Enrichment) Has(other Enrichment) bool { return e&other == other }
+
+// SearchOptions describes per-call options for searches
+type SearchOptions struct {
+	// enrichments to perform on each Entry returned by the search
+	Enrichments Enrichment
+	// maximum number of Entries to enrich concurrently; see PopulateOptions
+	Concurrency int
+	// whether to request every extended attribute the indexer supports, such
+	// as those collected in MediaInfo
+	Extended bool
+}
+
+// apply sets the request parameters implied by the options
+func (o SearchOptions) apply(values url.Values) {
+	if o.Extended {
+		values.Set("extended", "1")
+	}
+}
+
+// searchOptions returns the SearchOptions used by search methods that do not
+// accept SearchOptions explicitly; they are derived from the Client's defaults
+func (c *Client) searchOptions() SearchOptions {
+	return SearchOptions{
+		Enrichments: c.Enrichments,
+		Concurrency: c.EnrichmentConcurrency,
+		Extended:    c.Extended,
+	}
+}
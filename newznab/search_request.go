@@ -0,0 +1,248 @@
+package newznab
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SearchRequest describes a search against the newznab API.  It covers the
+// parameters of the search, tvsearch, movie, music and book functions; fields
+// left at their zero value are omitted from the request.
+type SearchRequest struct {
+	// API function to use, e.g. FunctionTVSearch; defaults to FunctionSearch
+	Function string
+	// free text query
+	Query string
+	// categories to restrict the search to
+	Categories []Category
+	// maximum number of results to return
+	Limit int
+	// number of results to skip
+	Offset int
+	// only return results posted within this many days
+	MaxAge int
+	// extended attributes to include in results, e.g. "poster", "group"
+	Attrs []string
+	// whether to include all extended attributes in results
+	Extended bool
+	// only return results at least this many bytes in size
+	MinSize uint64
+	// only return results at most this many bytes in size
+	MaxSize uint64
+
+	// TVRage ID of the series (tvsearch)
+	TVRageID TVRageID
+	// TheTVDB ID of the series (tvsearch)
+	TVDBID TVDBID
+	// TVMaze ID of the series (tvsearch)
+	TVMazeID TVMazeID
+	// season to search for, e.g. "10" (tvsearch)
+	Season string
+	// episode to search for, e.g. "1" or "05/17" (tvsearch)
+	Episode string
+	// episodes to search for (tvsearch); used when Season and Episode are
+	// empty.  A range of episodes searches its whole season; a range of
+	// absolutely numbered episodes cannot be searched for, and fails
+	// validation.
+	EpisodeSpec EpisodeSpec
+
+	// IMDB ID of the series or movie (tvsearch, movie)
+	IMDBID IMDBID
+	// TheMovieDB ID of the series or movie (tvsearch, movie)
+	TMDBID TMDBID
+	// Trakt ID of the series or movie (tvsearch, movie)
+	TraktID TraktID
+	// genre to restrict the search to (movie, music)
+	Genre string
+
+	// artist to search for (music)
+	Artist string
+	// album to search for (music)
+	Album string
+	// record label to search for (music)
+	Label string
+	// track to search for (music)
+	Track string
+	// year of release (music)
+	Year int
+
+	// author to search for (book)
+	Author string
+	// title to search for (book)
+	Title string
+}
+
+// function returns the API function of the request, defaulting to
+// FunctionSearch
+func (r SearchRequest) function() string {
+	if r.Function == "" {
+		return FunctionSearch
+	}
+	return r.Function
+}
+
+// searchParams returns the function specific parameters set on the request;
+// these are the parameters an indexer lists as supportedParams in its
+// capabilities
+func (r SearchRequest) searchParams() url.Values {
+	values := url.Values{}
+	setString := func(key, value string) {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	setInt := func(key string, value int) {
+		if value != 0 {
+			values.Set(key, strconv.Itoa(value))
+		}
+	}
+	setID := func(key string, value int64) {
+		if value != 0 {
+			values.Set(key, strconv.FormatInt(value, 10))
+		}
+	}
+
+	setString("q", r.Query)
+	setID("rid", int64(r.TVRageID))
+	setID("tvdbid", int64(r.TVDBID))
+	setID("tvmazeid", int64(r.TVMazeID))
+	season, episode := r.Season, r.Episode
+	if season == "" && episode == "" {
+		season, episode = r.EpisodeSpec.searchParams()
+	}
+	setString("season", season)
+	setString("ep", episode)
+	if r.IMDBID != 0 {
+		values.Set("imdbid", r.IMDBID.Digits())
+	}
+	setID("tmdbid", int64(r.TMDBID))
+	setID("traktid", int64(r.TraktID))
+	setString("genre", r.Genre)
+	setString("artist", r.Artist)
+	setString("album", r.Album)
+	setString("label", r.Label)
+	setString("track", r.Track)
+	setInt("year", r.Year)
+	setString("author", r.Author)
+	setString("title", r.Title)
+	return values
+}
+
+// Values returns the query parameters for the request, excluding credentials
+func (r SearchRequest) Values() url.Values {
+	values := r.searchParams()
+	values.Set("t", r.function())
+	if len(r.Categories) > 0 {
+		values["cat"] = stringifyCategories(r.Categories)
+	}
+	if r.Limit != 0 {
+		values.Set("limit", strconv.Itoa(r.Limit))
+	}
+	if r.Offset != 0 {
+		values.Set("offset", strconv.Itoa(r.Offset))
+	}
+	if r.MaxAge != 0 {
+		values.Set("maxage", strconv.Itoa(r.MaxAge))
+	}
+	if len(r.Attrs) > 0 {
+		values.Set("attrs", strings.Join(r.Attrs, ","))
+	}
+	if r.Extended {
+		values.Set("extended", "1")
+	}
+	if r.MinSize != 0 {
+		values.Set("minsize", strconv.FormatUint(r.MinSize, 10))
+	}
+	if r.MaxSize != 0 {
+		values.Set("maxsize", strconv.FormatUint(r.MaxSize, 10))
+	}
+	return values
+}
+
+// SearchValidationError describes why a SearchRequest cannot be performed
+// against an indexer
+type SearchValidationError struct {
+	// API function of the request
+	Function string
+	// offending parameter, if any
+	Param string
+	// reason the request is invalid
+	Reason string
+}
+
+// Error implements the error interface for SearchValidationError
+func (e *SearchValidationError) Error() string {
+	if e.Param != "" {
+		return fmt.Sprintf("invalid %s request: parameter %s %s", e.Function, e.Param, e.Reason)
+	}
+	return fmt.Sprintf("invalid %s request: %s", e.Function, e.Reason)
+}
+
+// Validate checks the request for internal consistency, and that the
+// indexer's capabilities support its function and parameters.  It returns a
+// *SearchValidationError describing the first problem found.
+func (r SearchRequest) Validate(caps Capabilities) error {
+	function := r.function()
+	mode, ok := caps.Searching.Mode(function)
+	if !ok {
+		return &SearchValidationError{Function: function, Reason: "is not a known search function"}
+	}
+	if !mode.Available {
+		return &SearchValidationError{Function: function, Reason: "is not available on this indexer"}
+	}
+
+	if r.Season == "" && r.Episode == "" && r.EpisodeSpec.Kind == EpisodeAbsolute && r.EpisodeSpec.IsRange() {
+		return &SearchValidationError{Function: function, Param: "ep", Reason: "cannot select a range of absolutely numbered episodes"}
+	}
+
+	params := r.searchParams()
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !mode.SupportsParam(key) {
+			return &SearchValidationError{Function: function, Param: key, Reason: "is not supported by this indexer"}
+		}
+	}
+
+	if r.Limit < 0 || r.Offset < 0 || r.MaxAge < 0 {
+		return &SearchValidationError{Function: function, Reason: "limit, offset and maxage must not be negative"}
+	}
+	if caps.Limits.Max > 0 && r.Limit > caps.Limits.Max {
+		return &SearchValidationError{Function: function, Param: "limit", Reason: fmt.Sprintf("exceeds the indexer maximum of %d", caps.Limits.Max)}
+	}
+	if r.MaxSize != 0 && r.MinSize > r.MaxSize {
+		return &SearchValidationError{Function: function, Param: "minsize", Reason: "is greater than maxsize"}
+	}
+	return nil
+}
+
+// Do validates the request against the indexer's capabilities, fetching them
+// if they are not already cached, then performs it
+func (c *Client) Do(ctx context.Context, req SearchRequest) (SearchResult, error) {
+	caps, err := c.CapabilitiesContext(ctx)
+	if err != nil {
+		return SearchResult{}, err
+	}
+	if err = req.Validate(caps); err != nil {
+		return SearchResult{}, err
+	}
+	mapper := c.categoryMapper(caps)
+	if mapper != nil {
+		req.Categories = mapper.ToIndexer(req.Categories...)
+	}
+	result, err := c.SearchWithOptions(ctx, req.Values(), c.searchOptions())
+	if err != nil {
+		return SearchResult{}, err
+	}
+	if mapper != nil {
+		mapper.NormaliseEntries(result.Entries)
+	}
+	return result, nil
+}
@@ -0,0 +1,101 @@
+package newznab
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSearchRequestValues(t *testing.T) {
+	req := SearchRequest{
+		Function:   FunctionTVSearch,
+		Query:      "Bones",
+		Categories: []Category{CategoryTVSD, CategoryTVHD},
+		Limit:      100,
+		Offset:     50,
+		MaxAge:     30,
+		Attrs:      []string{"poster", "group"},
+		Extended:   true,
+		MinSize:    1024,
+		TVDBID:     75682,
+		Season:     "10",
+		Episode:    "1/12",
+	}
+	expected := "attrs=poster%2Cgroup&cat=5030%2C5040&ep=1%2F12&extended=1&limit=100&maxage=30&minsize=1024&offset=50&q=Bones&season=10&t=tvsearch&tvdbid=75682"
+	if encoded := req.Values().Encode(); encoded != expected {
+		t.Errorf("Wrong query parameters; got %v expected %v", encoded, expected)
+	}
+
+	if encoded := (SearchRequest{}).Values().Encode(); encoded != "t=search" {
+		t.Errorf("Empty request should default to t=search; got %v", encoded)
+	}
+}
+
+func TestSearchRequestValidate(t *testing.T) {
+	caps := Capabilities{
+		Limits: Limits{Max: 100},
+		Searching: Searching{
+			Search:      SearchMode{Available: true, SupportedParams: []string{"q"}},
+			TVSearch:    SearchMode{Available: true, SupportedParams: []string{"q", "tvdbid", "season", "ep"}},
+			MovieSearch: SearchMode{Available: false},
+		},
+	}
+	cases := []struct {
+		req   SearchRequest
+		param string
+		valid bool
+	}{
+		{SearchRequest{Query: "Bones"}, "", true},
+		{SearchRequest{Function: FunctionTVSearch, TVDBID: 75682, Season: "10", Episode: "1"}, "", true},
+		{SearchRequest{Function: FunctionTVSearch, TVRageID: 2870}, "rid", false},
+		{SearchRequest{Function: FunctionTVSearch, Query: "One Piece", EpisodeSpec: NewAbsoluteEpisode(1043)}, "", true},
+		{SearchRequest{Function: FunctionTVSearch, Query: "One Piece", EpisodeSpec: EpisodeSpec{Kind: EpisodeAbsolute, Episode: 1043, LastEpisode: 1045}}, "ep", false},
+		{SearchRequest{Function: FunctionMovieSearch, IMDBID: 364569}, "", false},
+		{SearchRequest{Function: "nonsense"}, "", false},
+		{SearchRequest{Query: "Bones", Limit: 500}, "limit", false},
+		{SearchRequest{Query: "Bones", MinSize: 10, MaxSize: 5}, "minsize", false},
+	}
+	for k, c := range cases {
+		err := c.req.Validate(caps)
+		if c.valid {
+			if err != nil {
+				t.Errorf("Case %d should be valid; %v", k, err)
+			}
+			continue
+		}
+		var validationErr *SearchValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Case %d should have returned a *SearchValidationError; got %v", k, err)
+		} else if validationErr.Param != c.param {
+			t.Errorf("Case %d reported wrong parameter; got %q expected %q", k, validationErr.Param, c.param)
+		}
+	}
+}
+
+func TestClientDo(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+
+	result, err := client.Do(context.Background(), SearchRequest{
+		Function:   FunctionTVSearch,
+		Categories: []Category{CategoryTVSD},
+		TVDBID:     75682,
+		Season:     "10",
+		Episode:    "1",
+	})
+	if err != nil {
+		t.Fatalf("Do failed; %v", err)
+	}
+	if len(result.Entries) == 0 {
+		t.Errorf("Do returned no results")
+	}
+	if result.Total != 72 {
+		t.Errorf("Wrong total; got %d expected %d", result.Total, 72)
+	}
+
+	_, err = client.Do(context.Background(), SearchRequest{Function: FunctionMusicSearch, Label: "Island"})
+	var validationErr *SearchValidationError
+	if !errors.As(err, &validationErr) || validationErr.Param != "label" {
+		t.Errorf("Music search by label should have failed validation; got %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package newznab
+
+import (
+	"context"
+	"net/url"
+)
+
+// SearchResult describes the response to a search: the Entries returned, and
+// information about the response and the feed it was returned in
+type SearchResult struct {
+	// entries returned by the search
+	Entries Entries
+	// total number of results matching the search, as reported by the
+	// indexer; this may be larger than len(Entries)
+	Total int
+	// offset of the first of Entries within all results
+	Offset int
+	// version of the newznab API spoken by the indexer, as advertised in its
+	// capabilities; empty if the capabilities have not been fetched by the
+	// Client, since search responses do not report it
+	APIVersion string
+	// information about the feed the results were returned in
+	Channel Channel
+}
+
+// Channel describes information about the RSS channel a search result was
+// returned in, such as the indexer's title and logo
+type Channel struct {
+	// title of the channel, usually the indexer's name
+	Title string
+	// description of the channel
+	Description string
+	// URL of the feed itself
+	Link *url.URL
+	// language of the channel, e.g. en-gb
+	Language string
+	// contact details of the channel's webmaster
+	Webmaster string
+	// category of the channel
+	Category string
+	// image, usually the indexer's logo, associated with the channel
+	Image ChannelImage
+}
+
+// ChannelImage describes the image associated with a Channel
+type ChannelImage struct {
+	// URL of the image
+	URL *url.URL
+	// title of the image
+	Title string
+	// URL the image links to
+	Link *url.URL
+	// description of the image
+	Description string
+	// width of the image in pixels, if specified
+	Width int
+	// height of the image in pixels, if specified
+	Height int
+}
+
+// searchResultFromURL performs a GET request against the given URL, and
+// returns the parsed SearchResult.  Any enrichments requested in opts are
+// performed on the resulting Entries.
+func (c *Client) searchResultFromURL(ctx context.Context, u *url.URL, opts SearchOptions) (SearchResult, error) {
+	feed, entries, err := c.feedFromURL(ctx, u, opts)
+	if err != nil {
+		return SearchResult{}, err
+	}
+	result := searchResultFromFeed(*feed, entries)
+	c.capabilitiesMu.Lock()
+	if c.capabilities != nil {
+		result.APIVersion = c.capabilities.Server.Version
+	}
+	c.capabilitiesMu.Unlock()
+	return result, nil
+}
+
+// searchResultFromFeed builds a SearchResult from a parsed feed and the
+// Entries produced from it
+func searchResultFromFeed(feed rawEntries, entries Entries) SearchResult {
+	channel := feed.Channel
+	return SearchResult{
+		Entries: entries,
+		Total:   channel.Response.Total,
+		Offset:  channel.Response.Offset,
+		Channel: Channel{
+			Title:       channel.Title,
+			Description: channel.Description,
+			Link:        parseOptionalURL(channel.Link.Href),
+			Language:    channel.Language,
+			Webmaster:   channel.Webmaster,
+			Category:    channel.Category,
+			Image: ChannelImage{
+				URL:         parseOptionalURL(channel.Image.URL),
+				Title:       channel.Image.Title,
+				Link:        parseOptionalURL(channel.Image.Link),
+				Description: channel.Image.Description,
+				Width:       channel.Image.Width,
+				Height:      channel.Image.Height,
+			},
+		},
+	}
+}
+
+// parseOptionalURL parses the given URL, returning nil if it is empty or
+// invalid
+func parseOptionalURL(raw string) *url.URL {
+	if raw == "" {
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil
+	}
+	return u
+}
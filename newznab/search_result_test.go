@@ -0,0 +1,60 @@
+package newznab
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+func TestSearchRSSWithOptions(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+	client.APIUserID = 1234
+
+	result, err := client.SearchRSSWithOptions(context.Background(), url.Values{
+		"num": []string{strconv.Itoa(50)},
+		"t":   stringifyCategories([]Category{CategoryMovieAll, CategoryTVAll}),
+		"dl":  []string{"1"},
+	}, SearchOptions{})
+	if err != nil {
+		t.Fatalf("Failed to search mock indexer; %v", err)
+	}
+
+	if len(result.Entries) != 50 {
+		t.Errorf("Wrong number of entries; got %d expected %d", len(result.Entries), 50)
+	}
+	if result.Total != 1714211 || result.Offset != 0 {
+		t.Errorf("Wrong total or offset; got %d, %d", result.Total, result.Offset)
+	}
+	if result.Channel.Title != "api.nzbgeek.info" {
+		t.Errorf("Wrong channel title; got %v", result.Channel.Title)
+	}
+	if result.Channel.Webmaster != "info@nzbgeek.info (NZBgeek)" {
+		t.Errorf("Wrong channel webmaster; got %v", result.Channel.Webmaster)
+	}
+	if img := result.Channel.Image.URL; img == nil || img.String() != "https://cdn.nzbgeek.info/covers/nzbgeek.png" {
+		t.Errorf("Wrong channel image; got %v", img)
+	}
+	if result.Channel.Link == nil || result.Channel.Link.Host != "api.nzbgeek.info" {
+		t.Errorf("Wrong channel link; got %v", result.Channel.Link)
+	}
+	if result.APIVersion != "" {
+		t.Errorf("APIVersion should be empty before the capabilities are fetched; got %v", result.APIVersion)
+	}
+
+	if _, err = client.Capabilities(); err != nil {
+		t.Fatalf("Failed to fetch capabilities; %v", err)
+	}
+	result, err = client.SearchRSSWithOptions(context.Background(), url.Values{
+		"num": []string{strconv.Itoa(50)},
+		"t":   stringifyCategories([]Category{CategoryMovieAll, CategoryTVAll}),
+		"dl":  []string{"1"},
+	}, SearchOptions{})
+	if err != nil {
+		t.Fatalf("Failed to search mock indexer; %v", err)
+	}
+	if result.APIVersion != "0.1" {
+		t.Errorf("Wrong API version; got %v expected %v", result.APIVersion, "0.1")
+	}
+}
@@ -1,29 +1,48 @@
 package newznab
 
 import (
+	"context"
+	"fmt"
 	"net/url"
 	"strconv"
-
-	"github.com/satori/go.uuid"
-	"github.com/smquartz/errors"
 )
 
 // SearchRSS performs an arbitrary RSS query against the torznab indexer, and
 // parses and returns the newznab entries the RSS API responded with
 func (c *Client) SearchRSS(values url.Values) (Entries, error) {
+	return c.SearchRSSContext(context.Background(), values)
+}
+
+// SearchRSSContext is like SearchRSS, but performs its requests with the
+// given context
+func (c *Client) SearchRSSContext(ctx context.Context, values url.Values) (Entries, error) {
+	result, err := c.SearchRSSWithOptions(ctx, values, c.searchOptions())
+	return result.Entries, err
+}
+
+// SearchRSSWithOptions is like SearchRSSContext, but uses the given
+// SearchOptions rather than the Client's defaults, and returns a SearchResult
+// including the feed metadata
+func (c *Client) SearchRSSWithOptions(ctx context.Context, values url.Values, opts SearchOptions) (SearchResult, error) {
 	values.Set("r", c.APIKey)
 	values.Set("i", strconv.Itoa(c.APIUserID))
-	return c.entriesFromURL(c.buildURL(ModePathRSS, values))
+	return c.searchResultFromURL(ctx, c.buildURL(ModePathRSS, values), opts)
 }
 
 // SearchRSSUntilEntryID fetches the RSS feed in chunks until it finds the
 // entry with the given ID, then stops and returns the newznab entries fetched
 // thus far.  If it reaches maxRequests, it will return what was fetched up
 // until that point.
-func (c *Client) SearchRSSUntilEntryID(categories []Category, num int, id uuid.UUID, maxRequests int) (entries Entries, err error) {
+func (c *Client) SearchRSSUntilEntryID(categories []Category, num int, id EntryID, maxRequests int) (entries Entries, err error) {
+	return c.SearchRSSUntilEntryIDContext(context.Background(), categories, num, id, maxRequests)
+}
+
+// SearchRSSUntilEntryIDContext is like SearchRSSUntilEntryID, but performs
+// its requests with the given context
+func (c *Client) SearchRSSUntilEntryIDContext(ctx context.Context, categories []Category, num int, id EntryID, maxRequests int) (entries Entries, err error) {
 	count := 0
 	for {
-		partition, err := c.SearchRSS(url.Values{
+		partition, err := c.SearchRSSContext(ctx, url.Values{
 			"num":    []string{strconv.Itoa(num)},
 			"t":      stringifyCategories(categories),
 			"dl":     []string{"1"},
@@ -31,10 +50,10 @@ func (c *Client) SearchRSSUntilEntryID(categories []Category, num int, id uuid.U
 		})
 		count++
 		if err != nil {
-			return nil, errors.Wrapf(err, "error getting RSS page %d", 1, count+1)
+			return nil, fmt.Errorf("error getting RSS page %d: %w", count, err)
 		}
 		for k, entry := range partition {
-			if entry.Meta.ID == id {
+			if !id.IsZero() && entry.Meta.ID == id {
 				return append(entries, partition[:k]...), nil
 			}
 		}
@@ -48,7 +67,13 @@ func (c *Client) SearchRSSUntilEntryID(categories []Category, num int, id uuid.U
 
 // SearchRecentEntries returns up to <num> of the most recent newznab entries
 func (c *Client) SearchRecentEntries(categories []Category, num int) (Entries, error) {
-	return c.SearchRSS(url.Values{
+	return c.SearchRecentEntriesContext(context.Background(), categories, num)
+}
+
+// SearchRecentEntriesContext is like SearchRecentEntries, but performs its
+// requests with the given context
+func (c *Client) SearchRecentEntriesContext(ctx context.Context, categories []Category, num int) (Entries, error) {
+	return c.SearchRSSContext(ctx, url.Values{
 		"num": []string{strconv.Itoa(num)},
 		"t":   stringifyCategories(categories),
 		"dl":  []string{"1"},
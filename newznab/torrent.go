@@ -0,0 +1,183 @@
+package newznab
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/smquartz/errors"
+	"github.com/smquartz/go-torznab/bencode"
+)
+
+// Metainfo describes the contents of a torrent metainfo (.torrent) file
+type Metainfo struct {
+	// suggested name of the file, or of the directory for multi-file torrents
+	Name string
+	// number of bytes in each piece
+	PieceLength int64
+	// SHA1 hash of each piece, in order
+	Pieces [][]byte
+	// files described by the torrent; single-file torrents have exactly one
+	// entry, whose path is the name of the torrent
+	Files []TorrentContent
+	// URL of the tracker
+	Announce string
+	// tiers of tracker URLs, as per BEP 12
+	AnnounceList [][]string
+	// whether the torrent is private, as per BEP 27
+	Private bool
+	// time the torrent was created
+	CreationDate time.Time
+	// free-form comment left by the author
+	Comment string
+	// program used to create the torrent
+	CreatedBy string
+	// v1 infohash; the SHA1 hash of the bencoded info dictionary
+	InfoHash []byte
+}
+
+// TorrentContent describes a single file within a torrent
+type TorrentContent struct {
+	// path components of the file, relative to the torrent's directory
+	Path []string
+	// length of the file in bytes
+	Length int64
+}
+
+// TotalLength returns the combined length of all files in the torrent
+func (m Metainfo) TotalLength() (total int64) {
+	for _, f := range m.Files {
+		total += f.Length
+	}
+	return total
+}
+
+// InfoHashMismatchError is returned when the infohash computed from a
+// downloaded torrent does not match the infohash advertised by the indexer
+type InfoHashMismatchError struct {
+	// infohash advertised by the indexer
+	Expected []byte
+	// infohash computed from the torrent file
+	Actual []byte
+}
+
+// Error implements the error interface
+func (e *InfoHashMismatchError) Error() string {
+	return fmt.Sprintf("torrent infohash %x does not match advertised infohash %x", e.Actual, e.Expected)
+}
+
+// ParseMetainfo parses the bytes of a torrent metainfo file
+func ParseMetainfo(data []byte) (*Metainfo, error) {
+	decoded, err := bencode.Decode(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decoding torrent", 1)
+	}
+	root, ok := decoded.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("torrent is not a dictionary")
+	}
+	info, ok := root["info"].(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("torrent has no info dictionary")
+	}
+	rawInfo, err := bencode.RawDictValue(data, "info")
+	if err != nil {
+		return nil, errors.Wrapf(err, "error extracting info dictionary", 1)
+	}
+	hash := sha1.Sum(rawInfo)
+
+	m := &Metainfo{InfoHash: hash[:]}
+	m.Announce, _ = root["announce"].(string)
+	m.Comment, _ = root["comment"].(string)
+	m.CreatedBy, _ = root["created by"].(string)
+	if created, ok := root["creation date"].(int64); ok {
+		m.CreationDate = time.Unix(created, 0).UTC()
+	}
+	if tiers, ok := root["announce-list"].([]interface{}); ok {
+		for _, tier := range tiers {
+			urls, ok := tier.([]interface{})
+			if !ok {
+				continue
+			}
+			var parsedTier []string
+			for _, u := range urls {
+				if s, ok := u.(string); ok {
+					parsedTier = append(parsedTier, s)
+				}
+			}
+			if len(parsedTier) > 0 {
+				m.AnnounceList = append(m.AnnounceList, parsedTier)
+			}
+		}
+	}
+
+	if m.Name, ok = info["name"].(string); !ok {
+		return nil, errors.Errorf("torrent info has no name")
+	}
+	if m.PieceLength, ok = info["piece length"].(int64); !ok || m.PieceLength <= 0 {
+		return nil, errors.Errorf("torrent info has no valid piece length")
+	}
+	pieces, ok := info["pieces"].(string)
+	if !ok || len(pieces)%sha1.Size != 0 {
+		return nil, errors.Errorf("torrent info has no valid piece hashes")
+	}
+	for i := 0; i < len(pieces); i += sha1.Size {
+		m.Pieces = append(m.Pieces, []byte(pieces[i:i+sha1.Size]))
+	}
+	if private, ok := info["private"].(int64); ok {
+		m.Private = private == 1
+	}
+
+	if length, ok := info["length"].(int64); ok {
+		m.Files = []TorrentContent{{Path: []string{m.Name}, Length: length}}
+	} else if files, ok := info["files"].([]interface{}); ok {
+		for i, f := range files {
+			file, ok := f.(map[string]interface{})
+			if !ok {
+				return nil, errors.Errorf("torrent file %d is not a dictionary", i)
+			}
+			content := TorrentContent{}
+			if content.Length, ok = file["length"].(int64); !ok {
+				return nil, errors.Errorf("torrent file %d has no length", i)
+			}
+			path, ok := file["path"].([]interface{})
+			if !ok || len(path) == 0 {
+				return nil, errors.Errorf("torrent file %d has no path", i)
+			}
+			for _, component := range path {
+				s, ok := component.(string)
+				if !ok {
+					return nil, errors.Errorf("torrent file %d has an invalid path", i)
+				}
+				content.Path = append(content.Path, s)
+			}
+			m.Files = append(m.Files, content)
+		}
+	} else {
+		return nil, errors.Errorf("torrent info has neither length nor files")
+	}
+
+	return m, nil
+}
+
+// applyMetainfo checks the parsed metainfo against the information advertised
+// by the indexer, and fills in anything the indexer did not advertise
+func (t *TorrentFile) applyMetainfo(m *Metainfo) error {
+	if len(t.InfoHash) > 0 && !bytes.Equal(t.InfoHash, m.InfoHash) {
+		return &InfoHashMismatchError{Expected: t.InfoHash, Actual: m.InfoHash}
+	}
+	t.InfoHash = m.InfoHash
+	t.Metainfo = m
+	if t.ContentsSize == 0 {
+		t.ContentsSize = uint64(m.TotalLength())
+	}
+	if t.Files == 0 {
+		t.Files = uint64(len(m.Files))
+	}
+	return nil
+}
+
+// InfoHashString returns the infohash of the torrent as a hexadecimal string
+func (t TorrentFile) InfoHashString() string { return hex.EncodeToString(t.InfoHash) }
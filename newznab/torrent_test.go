@@ -0,0 +1,93 @@
+package newznab
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const westworldInfoHash = "94bd0f683f549798064a4fa0c4d4f019bd048161"
+
+func TestParseMetainfo(t *testing.T) {
+	data, err := ioutil.ReadFile("../tests/fixtures/torrents/westworld.torrent")
+	if err != nil {
+		t.Fatalf("Failed to read fixture; %v", err)
+	}
+	m, err := ParseMetainfo(data)
+	if err != nil {
+		t.Fatalf("Failed to parse metainfo; %v", err)
+	}
+	if hex.EncodeToString(m.InfoHash) != westworldInfoHash {
+		t.Errorf("Wrong infohash; got %x expected %v", m.InfoHash, westworldInfoHash)
+	}
+	if m.Name != "Westworld.S01.720p.HDTV.x264" || m.PieceLength != 262144 || len(m.Pieces) != 3 {
+		t.Errorf("Wrong info; got %v, %v, %d pieces", m.Name, m.PieceLength, len(m.Pieces))
+	}
+	expectedFiles := []TorrentContent{
+		{Path: []string{"Westworld.S01E01.mkv"}, Length: 524288},
+		{Path: []string{"Subs", "English.srt"}, Length: 131072},
+		{Path: []string{"westworld.nfo"}, Length: 1024},
+	}
+	if !reflect.DeepEqual(m.Files, expectedFiles) {
+		t.Errorf("Wrong files; got %+v", m.Files)
+	}
+	if m.TotalLength() != 656384 {
+		t.Errorf("Wrong total length; got %d", m.TotalLength())
+	}
+	expectedTiers := [][]string{{"http://tracker.example/announce"}, {"udp://backup.example:6969/announce"}}
+	if m.Announce != "http://tracker.example/announce" || !reflect.DeepEqual(m.AnnounceList, expectedTiers) {
+		t.Errorf("Wrong trackers; got %v, %v", m.Announce, m.AnnounceList)
+	}
+	if !m.Private || !m.CreationDate.Equal(time.Unix(1475471564, 0)) || m.CreatedBy != "mktorrent 1.0" {
+		t.Errorf("Wrong metadata; got %v, %v, %v", m.Private, m.CreationDate, m.CreatedBy)
+	}
+
+	if _, err = ParseMetainfo([]byte("d4:infod4:name1:xee")); err == nil {
+		t.Errorf("ParseMetainfo should have errored on a torrent without pieces")
+	}
+}
+
+func TestTorrentFilePopulate(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "../tests/fixtures/torrents/westworld.torrent")
+	}))
+	defer ts.Close()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse mock server URL")
+	}
+	client := &Client{HTTPClient: &http.Client{}, BaseURL: u}
+
+	expected, _ := hex.DecodeString(westworldInfoHash)
+	torrent := NewTorrentFile()
+	torrent.DownloadURL = u
+	torrent.InfoHash = expected
+	if err = torrent.PopulateContext(context.Background(), client, &Entry{}); err != nil {
+		t.Fatalf("Failed to populate torrent; %v", err)
+	}
+	if torrent.Metainfo == nil || len(torrent.Raw) == 0 {
+		t.Fatalf("Torrent metainfo not populated")
+	}
+	if torrent.Files != 3 || torrent.ContentsSize != 656384 {
+		t.Errorf("Unadvertised fields not filled in; got %d files, %d bytes", torrent.Files, torrent.ContentsSize)
+	}
+
+	mismatched := NewTorrentFile()
+	mismatched.DownloadURL = u
+	mismatched.InfoHash = make([]byte, 20)
+	err = mismatched.PopulateContext(context.Background(), client, &Entry{})
+	var mismatch *InfoHashMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Expected *InfoHashMismatchError; got %v", err)
+	}
+	if mismatched.Metainfo != nil || mismatched.Raw != nil {
+		t.Errorf("Mismatched torrent should be left unchanged")
+	}
+}
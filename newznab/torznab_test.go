@@ -0,0 +1,122 @@
+package newznab
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSearchTorznabAttributes(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+
+	result, err := client.Do(context.Background(), SearchRequest{
+		Function:   FunctionSearch,
+		Query:      "Westworld",
+		Categories: []Category{CategoryTVAll},
+	})
+	if err != nil {
+		t.Fatalf("Failed to search mock indexer; %v", err)
+	}
+	if len(result.Entries) != 2 {
+		t.Fatalf("Wrong number of results; got %d expected %d", len(result.Entries), 2)
+	}
+
+	episode := result.Entries[0]
+	torrent, ok := episode.File.(*TorrentFile)
+	if !ok {
+		t.Fatalf("File should be *TorrentFile; got %T", episode.File)
+	}
+	if torrent.Files != 3 || torrent.Seeders != 112 || torrent.Leechers != 7 || torrent.Peers != 119 {
+		t.Errorf("Wrong swarm statistics; got %+v", *torrent)
+	}
+	if episode.Meta.Grabs != 824 {
+		t.Errorf("Wrong number of grabs; got %d expected %d", episode.Meta.Grabs, 824)
+	}
+	if torrent.MagnetURL == nil || torrent.MagnetURL.Scheme != "magnet" {
+		t.Errorf("Wrong magnet URL; got %v", torrent.MagnetURL)
+	}
+	if !torrent.IsFreeleech() || torrent.UploadVolumeFactor != 2 {
+		t.Errorf("Wrong volume factors; got %v, %v", torrent.DownloadVolumeFactor, torrent.UploadVolumeFactor)
+	}
+	if torrent.MinimumRatio != 1.5 || torrent.MinimumSeedTime != 48*time.Hour {
+		t.Errorf("Wrong seeding requirements; got %v, %v", torrent.MinimumRatio, torrent.MinimumSeedTime)
+	}
+	if !reflect.DeepEqual(torrent.Tags, []string{"freeleech", "internal"}) {
+		t.Errorf("Wrong tags; got %v", torrent.Tags)
+	}
+
+	tv, ok := episode.Content.(*TV)
+	if !ok {
+		t.Fatalf("Content should be *TV; got %T", episode.Content)
+	}
+	if tv.TVDBID != 296762 || tv.TVMazeID != 1371 || tv.TMDBID != 63247 || tv.TraktID != 99718 || tv.DoubanID != 25848316 {
+		t.Errorf("Wrong external IDs; got %+v", *tv)
+	}
+
+	// the movie has no movie specific attributes, so the shared IDs are
+	// attributed using its category
+	movie := result.Entries[1]
+	film, ok := movie.Content.(*Movie)
+	if !ok {
+		t.Fatalf("Content should be *Movie; got %T", movie.Content)
+	}
+	if film.TMDBID != 2362 || film.TraktID != 1829 {
+		t.Errorf("Wrong external IDs; got %+v", *film)
+	}
+	torrent, ok = movie.File.(*TorrentFile)
+	if !ok {
+		t.Fatalf("File should be *TorrentFile; got %T", movie.File)
+	}
+	if torrent.IsFreeleech() || torrent.DownloadVolumeFactor != 1 || torrent.UploadVolumeFactor != 1 {
+		t.Errorf("Volume factors should default to 1; got %v, %v", torrent.DownloadVolumeFactor, torrent.UploadVolumeFactor)
+	}
+}
+
+func TestSearchMalformedTorznabAttributes(t *testing.T) {
+	client, ts := newMockClient(t)
+	defer ts.Close()
+
+	result, err := client.Do(context.Background(), SearchRequest{
+		Function:   FunctionSearch,
+		Query:      "Malformed",
+		Categories: []Category{CategoryTVAll},
+	})
+	if err != nil {
+		t.Fatalf("Malformed attributes should not fail the search; %v", err)
+	}
+	if len(result.Entries) != 1 {
+		t.Fatalf("Wrong number of results; got %d expected %d", len(result.Entries), 1)
+	}
+
+	entry := result.Entries[0]
+	torrent, ok := entry.File.(*TorrentFile)
+	if !ok {
+		t.Fatalf("File should be *TorrentFile; got %T", entry.File)
+	}
+	if torrent.Seeders != 12 || torrent.Peers != 20 || torrent.Leechers != 0 {
+		t.Errorf("Well formed attributes should still be applied; got %+v", *torrent)
+	}
+	if tv, ok := entry.Content.(*TV); !ok || tv.TVDBID != 81189 {
+		t.Errorf("Content should still be parsed; got %+v", entry.Content)
+	}
+	var warned []string
+	for _, warning := range entry.Meta.Warnings {
+		warned = append(warned, warning.Field)
+	}
+	expected := []string{"leechers", "files", "downloadvolumefactor", "uploadvolumefactor", "minimumratio", "minimumseedtime", "infohash", "rating"}
+	if !reflect.DeepEqual(warned, expected) {
+		t.Errorf("Wrong warnings; got %v expected %v", warned, expected)
+	}
+}
+
+func TestTorrentAttributeOnNZBFile(t *testing.T) {
+	entry := Entry{File: new(NZBFile)}
+	if err := entry.fromRawTorrentAttribute(Attribute{Name: "seeders", Value: "12"}); err != nil {
+		t.Fatalf("A torrent attribute on a NZB should not fail; %v", err)
+	}
+	if _, ok := entry.File.(*NZBFile); !ok || len(entry.Meta.Warnings) != 1 {
+		t.Errorf("Attribute should be ignored with a warning; got %T, %v", entry.File, entry.Meta.Warnings)
+	}
+}